@@ -1,6 +1,9 @@
 package main
 
 import (
+	"context"
+	"log"
+
 	"github.com/Jake-Barrow/terraform-provider-mssql/mssql"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 )
@@ -15,4 +18,7 @@ func main() {
   plugin.Serve(&plugin.ServeOpts{
     ProviderFunc: mssql.New(version, commit),
   })
+  if err := mssql.ShutdownTracing(context.Background()); err != nil {
+    log.Printf("[ERROR] error exporting traces: %v", err)
+  }
 }
@@ -0,0 +1,52 @@
+package mssql
+
+import (
+	"context"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// auditResource wraps the create, update and delete functions of r so that
+// the statements they executed are written to the audit table, if any, once
+// they succeeded. It expects the operation in the context, see traceResource.
+func auditResource(r *schema.Resource) {
+	if r.CreateContext != nil {
+		r.CreateContext = auditCRUD(r.CreateContext, false)
+	}
+	if r.UpdateContext != nil {
+		r.UpdateContext = auditCRUD(r.UpdateContext, false)
+	}
+	if r.DeleteContext != nil {
+		r.DeleteContext = auditCRUD(r.DeleteContext, true)
+	}
+}
+
+func auditCRUD(fn crudFunc, deletes bool) crudFunc {
+	return func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		op, _ := model.OperationFromContext(ctx)
+		op.Audit = &model.AuditLog{}
+		// The ID of a created resource is only known afterwards, and the ID of
+		// a deleted one may be cleared.
+		id := data.Id()
+
+		diags := fn(model.WithOperation(ctx, op), data, meta)
+		if diags.HasError() {
+			return diags
+		}
+		if !deletes {
+			id = data.Id()
+		}
+		// The change is made, so failing to audit it must not fail the
+		// operation and lose track of the resource.
+		if err := op.Audit.Write(ctx, id); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Unable to write the audit record",
+				Detail:   err.Error(),
+			})
+		}
+		return diags
+	}
+}
@@ -0,0 +1,36 @@
+package mssql
+
+import (
+	"context"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type CapabilitiesConnector interface {
+	GetServerCapabilities(ctx context.Context) (*model.ServerCapabilities, error)
+}
+
+// planServerCapabilities returns the capabilities of the server of a resource
+// being planned, or nil when they are unknown, e.g. because the server block
+// refers to resources that are not created yet or the server is unreachable.
+// Plan-time checks are skipped then, and left to the server on apply.
+func planServerCapabilities(ctx context.Context, meta interface{}, data *schema.ResourceDiff, resource string) *model.ServerCapabilities {
+	logger := loggerFromMeta(ctx, meta, resource, "plan")
+
+	provider := meta.(model.Provider)
+	server, err := provider.GetServer(serverProp, data)
+	if err != nil || server["host"] == "" {
+		return nil
+	}
+	connector, err := provider.GetConnector(serverProp, data)
+	if err != nil {
+		return nil
+	}
+	capabilities, err := connector.(CapabilitiesConnector).GetServerCapabilities(ctx)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Unable to detect server capabilities, skipping plan-time checks")
+		return nil
+	}
+	return capabilities
+}
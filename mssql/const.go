@@ -2,6 +2,30 @@ package mssql
 
 const (
 	serverProp               = "server"
+	serverRefProp            = "server_ref"
+	serverProfileProp        = "server_profile"
+	nameProp                 = "name"
+	maxOpenConnsProp         = "max_open_connections"
+	maxIdleConnsProp         = "max_idle_connections"
+	retryProp                = "retry"
+	logPathProp              = "log_path"
+	logLevelProp             = "log_level"
+	logFormatProp            = "log_format"
+	logMaxSizeProp           = "log_max_size"
+	tracingExporterProp      = "tracing_exporter"
+	tracingEndpointProp      = "tracing_endpoint"
+	tracingFileProp          = "tracing_file"
+	maxAttemptsProp          = "max_attempts"
+	backoffProp              = "backoff"
+	maxBackoffProp           = "max_backoff"
+	jitterProp               = "jitter"
+	readOnlyProp             = "read_only"
+	sqlOutputPathProp        = "sql_output_path"
+	auditTableProp           = "audit_table"
+	maxDatabaseWritesProp    = "max_database_writes"
+	writeLockProp            = "write_lock"
+	lockTimeoutProp          = "lock_timeout"
+	bulkReadProp             = "bulk_read"
 	ignoreDeletionProp       = "ignore_deletion"
 	databaseProp             = "database"
 	principalIdProp          = "principal_id"
@@ -14,8 +38,13 @@ const (
 	defaultSchemaProp        = "default_schema"
 	defaultDboPropDefault    = "dbo"
 	rolesProp                = "roles"
+	serverRolesProp          = "server_roles"
+	memberNameProp           = "member_name"
+	membersProp              = "members"
 	loginNameProp            = "login_name"
 	permissionsProp          = "permissions"
+	deniedPermissionsProp    = "denied_permissions"
+	principalNameProp        = "principal_name"
 	roleNameProp             = "role_name"
 	schemaNameProp           = "schema_name"
 	ownerNameProp            = "owner_name"
@@ -2,7 +2,6 @@ package mssql
 
 import (
 	"context"
-	"strings"
 
 	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -17,12 +16,13 @@ func datasourceAzureExternalDatasource() *schema.Resource {
 			serverProp: {
 				Type:     schema.TypeList,
 				MaxItems: 1,
-				Required: true,
+				Optional: true,
 				ForceNew: true,
 				Elem: &schema.Resource{
 					Schema: getServerSchema(serverProp),
 				},
 			},
+			serverRefProp: getServerRefSchema(),
 			databaseProp: {
 				Type:     schema.TypeString,
 				Required: true,
@@ -66,7 +66,7 @@ func datasourceAzureExternalDatasource() *schema.Resource {
 }
 
 func datasourceAzureExternalDatasourceRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "azureexternaldatasource", "read")
+	logger := loggerFromMeta(ctx, meta, "azureexternaldatasource", "read")
 	logger.Debug().Msgf("Read %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -77,11 +77,11 @@ func datasourceAzureExternalDatasourceRead(ctx context.Context, data *schema.Res
 		return diag.FromErr(err)
 	}
 
-	mssqlversion, err := connector.GetMSSQLVersion(ctx)
+	caps, err := connector.GetServerCapabilities(ctx)
 	if err != nil {
-		return diag.FromErr(errors.Wrap(err, "unable to get MSSQL version"))
+		return diag.FromErr(errors.Wrap(err, "unable to detect server capabilities"))
 	}
-	if !strings.Contains(mssqlversion, "Microsoft SQL Azure") {
+	if !caps.IsAzure() {
 		return diag.Errorf("Error: The database is not an Azure SQL Database.")
 	}
 
@@ -114,7 +114,11 @@ func datasourceAzureExternalDatasourceRead(ctx context.Context, data *schema.Res
 		if err = data.Set(rdatabasenameProp, datasource.RDatabaseName); err != nil {
 			return diag.FromErr(err)
 		}
-		data.SetId(getAzureExternalDatasourceID(data))
+		id, err := getAzureExternalDatasourceID(meta, data)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		data.SetId(id)
 	}
 
 	return nil
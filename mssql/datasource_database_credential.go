@@ -16,12 +16,13 @@ func datasourceDatabaseCredential() *schema.Resource {
 			serverProp: {
 				Type:     schema.TypeList,
 				MaxItems: 1,
-				Required: true,
+				Optional: true,
 				ForceNew: true,
 				Elem: &schema.Resource{
 					Schema: getServerSchema(serverProp),
 				},
 			},
+			serverRefProp: getServerRefSchema(),
 			databaseProp: {
 				Type:     schema.TypeString,
 				Required: true,
@@ -53,7 +54,7 @@ func datasourceDatabaseCredential() *schema.Resource {
 }
 
 func datasourceDatabaseCredentialRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "databasecredential", "read")
+	logger := loggerFromMeta(ctx, meta, "databasecredential", "read")
 	logger.Debug().Msgf("Read %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -84,7 +85,11 @@ func datasourceDatabaseCredentialRead(ctx context.Context, data *schema.Resource
 		if err = data.Set(credentialIdProp, scopedcredential.CredentialID); err != nil {
 			return diag.FromErr(err)
 		}
-		data.SetId(getDatabaseCredentialID(data))
+		id, err := getDatabaseCredentialID(meta, data)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		data.SetId(id)
 	}
 
 	return nil
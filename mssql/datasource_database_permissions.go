@@ -16,12 +16,13 @@ func dataSourceDatabasePermissions() *schema.Resource {
 			serverProp: {
 				Type:     schema.TypeList,
 				MaxItems: 1,
-				Required: true,
+				Optional: true,
 				ForceNew: true,
 				Elem: &schema.Resource{
 					Schema: getServerSchema(serverProp),
 				},
 			},
+			serverRefProp: getServerRefSchema(),
 			databaseProp: {
 				Type:     schema.TypeString,
 				Required: true,
@@ -52,7 +53,7 @@ func dataSourceDatabasePermissions() *schema.Resource {
 }
 
 func dataSourceDatabasePermissionsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "databasepermissions", "read")
+	logger := loggerFromMeta(ctx, meta, "databasepermissions", "read")
 	logger.Debug().Msgf("Read %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -83,7 +84,11 @@ func dataSourceDatabasePermissionsRead(ctx context.Context, data *schema.Resourc
 		if err = data.Set(permissionsProp, permissions.Permissions); err != nil {
 			return diag.FromErr(err)
 		}
-		data.SetId(getDatabasePermissionsID(data))
+		id, err := getDatabasePermissionsID(meta, data)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		data.SetId(id)
 	}
 
 	return nil
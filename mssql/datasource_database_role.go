@@ -16,11 +16,12 @@ func dataSourceDatabaseRole() *schema.Resource {
 			serverProp: {
 				Type:     schema.TypeList,
 				MaxItems: 1,
-				Required: true,
+				Optional: true,
 				Elem: &schema.Resource{
 					Schema: getServerSchema(serverProp),
 				},
 			},
+			serverRefProp: getServerRefSchema(),
 			databaseProp: {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -53,7 +54,7 @@ func dataSourceDatabaseRole() *schema.Resource {
 }
 
 func dataSourceDatabaseRoleRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "role", "read")
+	logger := loggerFromMeta(ctx, meta, "role", "read")
 	logger.Debug().Msgf("Read %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -85,7 +86,11 @@ func dataSourceDatabaseRoleRead(ctx context.Context, data *schema.ResourceData,
 		if err = data.Set(ownerIdProp, role.OwnerId); err != nil {
 			return diag.FromErr(err)
 		}
-		data.SetId(getDatabaseRoleID(data))
+		id, err := getDatabaseRoleID(meta, data)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		data.SetId(id)
 	}
 
 	return nil
@@ -16,11 +16,12 @@ func dataSourceDatabaseSchema() *schema.Resource {
 			serverProp: {
 				Type:     schema.TypeList,
 				MaxItems: 1,
-				Required: true,
+				Optional: true,
 				Elem: &schema.Resource{
 					Schema: getServerSchema(serverProp),
 				},
 			},
+			serverRefProp: getServerRefSchema(),
 			databaseProp: {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -53,7 +54,7 @@ func dataSourceDatabaseSchema() *schema.Resource {
 }
 
 func dataSourceDatabaseSchemaRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "schema", "read")
+	logger := loggerFromMeta(ctx, meta, "schema", "read")
 	logger.Debug().Msgf("Read %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -85,7 +86,11 @@ func dataSourceDatabaseSchemaRead(ctx context.Context, data *schema.ResourceData
 		if err = data.Set(ownerIdProp, sqlschema.OwnerId); err != nil {
 			return diag.FromErr(err)
 		}
-		data.SetId(getDatabaseSchemaID(data))
+		id, err := getDatabaseSchemaID(meta, data)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		data.SetId(id)
 	}
 
 	return nil
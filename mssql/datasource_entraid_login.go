@@ -15,11 +15,12 @@ func dataSourceEntraIDLogin() *schema.Resource {
 			serverProp: {
 				Type:     schema.TypeList,
 				MaxItems: 1,
-				Required: true,
+				Optional: true,
 				Elem: &schema.Resource{
 					Schema: getServerSchema(serverProp),
 				},
 			},
+			serverRefProp: getServerRefSchema(),
 			loginNameProp: {
 				Type:     schema.TypeString,
 				Required: true,
@@ -49,7 +50,7 @@ func dataSourceEntraIDLogin() *schema.Resource {
 }
 
 func dataSourceEntraIDLoginRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "EntraIDLogin", "read")
+	logger := loggerFromMeta(ctx, meta, "EntraIDLogin", "read")
 	logger.Debug().Msgf("Read %s", data.Id())
 
 	loginName := data.Get(loginNameProp).(string)
@@ -79,7 +80,11 @@ func dataSourceEntraIDLoginRead(ctx context.Context, data *schema.ResourceData,
 		if err = data.Set(defaultLanguageProp, EntraIDLogin.DefaultLanguage); err != nil {
 			return diag.FromErr(err)
 		}
-		data.SetId(getLoginID(data))
+		id, err := getLoginID(meta, data)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		data.SetId(id)
 	}
 
 	return nil
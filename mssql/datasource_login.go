@@ -15,11 +15,12 @@ func dataSourceLogin() *schema.Resource {
 			serverProp: {
 				Type:     schema.TypeList,
 				MaxItems: 1,
-				Required: true,
+				Optional: true,
 				Elem: &schema.Resource{
 					Schema: getServerSchema(serverProp),
 				},
 			},
+			serverRefProp: getServerRefSchema(),
 			loginNameProp: {
 				Type:     schema.TypeString,
 				Required: true,
@@ -49,7 +50,7 @@ func dataSourceLogin() *schema.Resource {
 }
 
 func dataSourceLoginRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "login", "read")
+	logger := loggerFromMeta(ctx, meta, "login", "read")
 	logger.Debug().Msgf("Read %s", data.Id())
 
 	loginName := data.Get(loginNameProp).(string)
@@ -79,7 +80,11 @@ func dataSourceLoginRead(ctx context.Context, data *schema.ResourceData, meta in
 		if err = data.Set(defaultLanguageProp, login.DefaultLanguage); err != nil {
 			return diag.FromErr(err)
 		}
-		data.SetId(getLoginID(data))
+		id, err := getLoginID(meta, data)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		data.SetId(id)
 	}
 
 	return nil
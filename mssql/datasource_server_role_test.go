@@ -0,0 +1,86 @@
+package mssql
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDataServerRole_Local_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckServerRoleDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDataServerRole(t, "data_local_test", "login", map[string]interface{}{"role_name": "data_test_server_role"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mssql_server_role.data_local_test", "id", "sqlserver://localhost:1433/server_role/data_test_server_role"),
+					resource.TestCheckResourceAttr("data.mssql_server_role.data_local_test", "role_name", "data_test_server_role"),
+					resource.TestCheckResourceAttr("data.mssql_server_role.data_local_test", "owner_name", os.Getenv("MSSQL_USERNAME")),
+					resource.TestCheckResourceAttrPair("data.mssql_server_role.data_local_test", "principal_id", "mssql_server_role.data_local_test", "principal_id"),
+					resource.TestCheckResourceAttrSet("data.mssql_server_role.data_local_test", "owning_principal_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataServerRole_Local_FixedRole(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "mssql_server_role" "sysadmin" {
+							server {
+								host = "localhost"
+								login {}
+							}
+							role_name = "sysadmin"
+						}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mssql_server_role.sysadmin", "principal_id", "3"),
+					resource.TestCheckResourceAttr("data.mssql_server_role.sysadmin", "owner_name", "sa"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDataServerRole(t *testing.T, name string, login string, data map[string]interface{}) string {
+	text := `resource "mssql_server_role" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				role_name = "{{ .role_name }}"
+			}
+			data "mssql_server_role" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				role_name = "{{ .role_name }}"
+				depends_on = [mssql_server_role.{{ .name }}]
+			}`
+
+	data["name"] = name
+	data["login"] = login
+	if login == "fedauth" || login == "msi" || login == "azure" {
+		data["host"] = os.Getenv("TF_ACC_SQL_SERVER")
+	} else if login == "login" {
+		data["host"] = "localhost"
+	} else {
+		t.Fatalf("login expected to be one of 'login', 'azure', 'msi', 'fedauth', got %s", login)
+	}
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return res
+}
@@ -15,11 +15,12 @@ func dataSourceUser() *schema.Resource {
 			serverProp: {
 				Type:     schema.TypeList,
 				MaxItems: 1,
-				Required: true,
+				Optional: true,
 				Elem: &schema.Resource{
 					Schema: getServerSchema(serverProp),
 				},
 			},
+			serverRefProp: getServerRefSchema(),
 			databaseProp: {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -74,7 +75,7 @@ func dataSourceUser() *schema.Resource {
 }
 
 func dataSourceUserRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "user", "read")
+	logger := loggerFromMeta(ctx, meta, "user", "read")
 	logger.Debug().Msgf("Read %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -114,7 +115,11 @@ func dataSourceUserRead(ctx context.Context, data *schema.ResourceData, meta int
 		if err = data.Set(rolesProp, user.Roles); err != nil {
 			return diag.FromErr(err)
 		}
-		data.SetId(getUserID(data))
+		id, err := getUserID(meta, data)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		data.SetId(id)
 	}
 
 	return nil
@@ -0,0 +1,74 @@
+package mssql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/rs/zerolog"
+)
+
+// fakeProvider is a model.Provider returning connector for every resource,
+// with localhost:1433 as the server, unless it has no default server.
+type fakeProvider struct {
+	connector       interface{}
+	noDefaultServer bool
+}
+
+func (p fakeProvider) GetServer(prefix string, data model.ResourceData) (map[string]interface{}, error) {
+	if server, ok := data.GetOk(prefix + ".0"); ok {
+		return server.(map[string]interface{}), nil
+	}
+	if p.noDefaultServer {
+		return nil, errors.New("no default server configured in the provider")
+	}
+	return map[string]interface{}{"host": "localhost", "port": "1433"}, nil
+}
+
+func (p fakeProvider) GetConnector(string, model.ResourceData) (interface{}, error) {
+	return p.connector, nil
+}
+
+func (p fakeProvider) ResourceLogger(context.Context, string, string) zerolog.Logger {
+	return zerolog.Nop()
+}
+
+func (p fakeProvider) DataSourceLogger(context.Context, string, string) zerolog.Logger {
+	return zerolog.Nop()
+}
+
+// updateData returns the resource data of r updating the resource in state
+// to config, with the raw configuration set as Terraform would.
+func updateData(t *testing.T, r *schema.Resource, state, config map[string]interface{}, meta interface{}) *schema.ResourceData {
+	t.Helper()
+
+	current := schema.TestResourceDataRaw(t, r.Schema, state)
+	current.SetId("id")
+	s := current.State()
+
+	diff, err := r.Diff(context.Background(), s, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil {
+		diff = &terraform.InstanceDiff{}
+	}
+	raw, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.RawConfig, err = ctyjson.Unmarshal(raw, r.CoreConfigSchema().ImpliedType()); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := schema.InternalMap(r.Schema).Data(s, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
@@ -0,0 +1,35 @@
+package mssql
+
+import (
+	"context"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// lockResource wraps the create, update and delete functions of r so that the
+// write locks taken by their statements, if the write lock is enabled, are
+// held until they return, including the writes of their audit records. It
+// expects the operation in the context, see traceResource.
+func lockResource(r *schema.Resource) {
+	if r.CreateContext != nil {
+		r.CreateContext = lockCRUD(r.CreateContext)
+	}
+	if r.UpdateContext != nil {
+		r.UpdateContext = lockCRUD(r.UpdateContext)
+	}
+	if r.DeleteContext != nil {
+		r.DeleteContext = lockCRUD(r.DeleteContext)
+	}
+}
+
+func lockCRUD(fn crudFunc) crudFunc {
+	return func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		op, _ := model.OperationFromContext(ctx)
+		op.Locks = &model.LockSet{}
+		defer op.Locks.Release()
+
+		return fn(model.WithOperation(ctx, op), data, meta)
+	}
+}
@@ -0,0 +1,166 @@
+package mssql
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// sensitiveLogFields are masked in every log entry of the provider.
+var sensitiveLogFields = []string{
+	"password",
+	"secret",
+	"client_secret",
+	"client_certificate_password",
+	"access_token",
+	"token",
+}
+
+// newLogger returns a zerolog logger that forwards its entries to the
+// terraform-plugin-log logger of ctx, so Terraform decides what is logged
+// and where, e.g. with TF_LOG_PROVIDER. Entries are also written to logFile,
+// if any.
+func newLogger(ctx context.Context, logFile zerolog.LevelWriter) zerolog.Logger {
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, sensitiveLogFields...)
+	var writer zerolog.LevelWriter = tflogWriter{ctx: ctx}
+	if logFile != nil {
+		writer = zerolog.MultiLevelWriter(writer, logFile)
+	}
+	return zerolog.New(writer).Level(zerolog.TraceLevel).With().Timestamp().Logger()
+}
+
+// newLogFile returns a writer to the provider log file at path that drops
+// entries below level, or nil when path is empty.
+func newLogFile(path, level, format string, maxSizeMB int) (zerolog.LevelWriter, error) {
+	if path == "" {
+		return nil, nil
+	}
+	logLevel, err := zerolog.ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	file, err := openLogFile(path, int64(maxSizeMB)*1024*1024)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening log file %s", path)
+	}
+
+	var writer io.Writer = file
+	if format == "console" {
+		writer = zerolog.ConsoleWriter{Out: file, NoColor: true, TimeFormat: time.RFC3339}
+	}
+	return &zerolog.FilteredLevelWriter{
+		Writer: zerolog.LevelWriterAdapter{Writer: writer},
+		Level:  logLevel,
+	}, nil
+}
+
+var (
+	logFilesMu sync.Mutex
+	logFiles   = make(map[string]*rotatingFile)
+)
+
+// openLogFile returns the log file at path, shared by all provider
+// configurations writing to it so they rotate it together.
+func openLogFile(path string, maxSize int64) (*rotatingFile, error) {
+	logFilesMu.Lock()
+	defer logFilesMu.Unlock()
+
+	if f, ok := logFiles[path]; ok {
+		return f, nil
+	}
+	f := &rotatingFile{path: path, maxSize: maxSize}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	logFiles[path] = f
+	return f, nil
+}
+
+// rotatingFile is a log file that is renamed to <path>.1, replacing the
+// previous one, and started anew once it grows beyond maxSize bytes. A
+// maxSize of 0 disables rotation.
+type rotatingFile struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+	size    int64
+	file    *os.File
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file, f.size = file, info.Size()
+	return nil
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(f.path, f.path+".1"); err != nil {
+		return err
+	}
+	return f.open()
+}
+
+type tflogWriter struct {
+	ctx context.Context
+}
+
+func (w tflogWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.DebugLevel, p)
+}
+
+func (w tflogWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(p, &fields); err != nil {
+		return 0, err
+	}
+	msg, _ := fields[zerolog.MessageFieldName].(string)
+	delete(fields, zerolog.MessageFieldName)
+	delete(fields, zerolog.LevelFieldName)
+	delete(fields, zerolog.TimestampFieldName)
+
+	switch level {
+	case zerolog.TraceLevel:
+		tflog.Trace(w.ctx, msg, fields)
+	case zerolog.DebugLevel:
+		tflog.Debug(w.ctx, msg, fields)
+	case zerolog.InfoLevel:
+		tflog.Info(w.ctx, msg, fields)
+	case zerolog.WarnLevel:
+		tflog.Warn(w.ctx, msg, fields)
+	default:
+		tflog.Error(w.ctx, msg, fields)
+	}
+	return len(p), nil
+}
@@ -1,7 +1,66 @@
 package model
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import (
+	"io"
+	"time"
+
+	"github.com/rs/zerolog"
+)
 
 type ConnectorFactory interface {
-	GetConnector(prefix string, data *schema.ResourceData) (interface{}, error)
+	Configure(config *ProviderConfig) ConnectorFactory
+	GetServer(prefix string, data ResourceData) (map[string]interface{}, error)
+	GetConnector(prefix string, data ResourceData) (interface{}, error)
+	Close() error
+}
+
+// ResourceData is what the server block of a resource is read from: a
+// *schema.ResourceData, or a *schema.ResourceDiff at plan time.
+type ResourceData interface {
+	GetOk(key string) (interface{}, bool)
+}
+
+// ProviderConfig holds the provider-level settings shared by all resources.
+type ProviderConfig struct {
+	// Server is used by resources with neither an inline server block nor a server_ref.
+	Server map[string]interface{}
+	// ServerProfiles are the named server blocks resources select with server_ref.
+	ServerProfiles map[string]map[string]interface{}
+	// MaxOpenConns and MaxIdleConns limit each pooled connection to a server and database.
+	MaxOpenConns int
+	MaxIdleConns int
+	// Logger writes to the provider log file, nil when there is none.
+	Logger *zerolog.Logger
+	// Retry is the policy for transient errors. A zero value selects the
+	// default policy of the connector factory.
+	Retry RetryPolicy
+	// ReadOnly makes connectors refuse every statement that is executed
+	// rather than queried.
+	ReadOnly bool
+	// SQLOutput, when set, receives the statements connectors would execute,
+	// and none of them is sent to the server.
+	SQLOutput io.Writer
+	// MaxDatabaseWrites limits the statements executed concurrently in each
+	// database. 0 means unlimited.
+	MaxDatabaseWrites int
+	// WriteLock makes every statement executed hold the application lock of
+	// its database, waiting at most WriteLockTimeout to acquire it.
+	WriteLock        bool
+	WriteLockTimeout time.Duration
+	// BulkRead makes connectors read the principals, role memberships and
+	// permissions of a database once for the refresh of all its resources.
+	BulkRead bool
+	// AuditTable, when set, is the [schema.]table of each database changes
+	// are recorded in.
+	AuditTable string
+}
+
+// RetryPolicy controls how often and how fast connection setup and
+// idempotent statements are retried after a transient error.
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+	// Jitter is the fraction of each delay that is randomized.
+	Jitter float64
 }
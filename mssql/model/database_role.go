@@ -6,4 +6,5 @@ type DatabaseRole struct {
 	RoleName  string
 	OwnerName string
 	OwnerId   int
+	Members   []string
 }
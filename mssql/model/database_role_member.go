@@ -0,0 +1,11 @@
+package model
+
+// DatabaseRoleMember represents the membership of a user or database role in
+// a database role
+type DatabaseRoleMember struct {
+	DatabaseName string
+	RoleID       int
+	RoleName     string
+	MemberID     int
+	MemberName   string
+}
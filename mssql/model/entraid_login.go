@@ -7,4 +7,5 @@ type EntraIDLogin struct {
   ObjectId        string
   Sid             string
   PrincipalID     int
+  ServerRoles     []string
 }
@@ -6,4 +6,5 @@ type Login struct {
 	SIDStr          string
 	DefaultDatabase string
 	DefaultLanguage string
+	ServerRoles     []string
 }
@@ -0,0 +1,119 @@
+package model
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Operation identifies the resource operation statements are run for.
+type Operation struct {
+	// Resource is the resource type, e.g. mssql_login.
+	Resource string
+	// Action is create, read, update, delete or import.
+	Action string
+	// ID is the resource ID, empty until the resource is created.
+	ID string
+	// Audit collects the changes of a create, update or delete, to be
+	// written to the audit table once the operation succeeded.
+	Audit *AuditLog
+	// Emitted is set once a statement of the operation is written to the SQL
+	// output instead of being executed.
+	Emitted *atomic.Bool
+	// Locks holds the write locks taken by the statements of a create,
+	// update or delete, to be released once the operation ended.
+	Locks *LockSet
+}
+
+type operationKey struct{}
+
+// WithOperation returns a copy of ctx carrying op.
+func WithOperation(ctx context.Context, op Operation) context.Context {
+	return context.WithValue(ctx, operationKey{}, op)
+}
+
+// OperationFromContext returns the operation of ctx, if any.
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	op, ok := ctx.Value(operationKey{}).(Operation)
+	return op, ok
+}
+
+// AuditLog collects the audit records of the statements executed for an
+// operation, as functions writing them for the final resource ID.
+type AuditLog struct {
+	mu     sync.Mutex
+	writes []func(ctx context.Context, id string) error
+}
+
+// Add appends the write of one audit record.
+func (a *AuditLog) Add(write func(ctx context.Context, id string) error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.writes = append(a.writes, write)
+}
+
+// Write writes the collected audit records for the resource id, and stops at
+// the first error.
+func (a *AuditLog) Write(ctx context.Context, id string) error {
+	a.mu.Lock()
+	writes := a.writes
+	a.writes = nil
+	a.mu.Unlock()
+
+	for _, write := range writes {
+		if err := write(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LockSet holds the locks taken by the statements of an operation, by key,
+// with the functions releasing them.
+type LockSet struct {
+	mu       sync.Mutex
+	locks    map[interface{}]interface{}
+	releases []func()
+}
+
+// Acquire returns the lock held under key, taking it with acquire if it is
+// not held yet. acquire returns the lock and the function releasing it.
+func (l *LockSet) Acquire(key interface{}, acquire func() (interface{}, func(), error)) (interface{}, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if lock, ok := l.locks[key]; ok {
+		return lock, nil
+	}
+	lock, release, err := acquire()
+	if err != nil {
+		return nil, err
+	}
+	if l.locks == nil {
+		l.locks = make(map[interface{}]interface{})
+	}
+	l.locks[key] = lock
+	l.releases = append(l.releases, release)
+	return lock, nil
+}
+
+// Get returns the lock held under key, if any.
+func (l *LockSet) Get(key interface{}) (interface{}, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lock, ok := l.locks[key]
+	return lock, ok
+}
+
+// Release releases the held locks, the last taken first.
+func (l *LockSet) Release() {
+	l.mu.Lock()
+	releases := l.releases
+	l.locks, l.releases = nil, nil
+	l.mu.Unlock()
+
+	for i := len(releases) - 1; i >= 0; i-- {
+		releases[i]()
+	}
+}
@@ -1,12 +1,16 @@
 package model
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"context"
+
 	"github.com/rs/zerolog"
 )
 
 type Provider interface {
-	GetConnector(prefix string, data *schema.ResourceData) (interface{}, error)
-	ResourceLogger(resource, function string) zerolog.Logger
-	DataSourceLogger(datasource, function string) zerolog.Logger
+	GetServer(prefix string, data ResourceData) (map[string]interface{}, error)
+	GetConnector(prefix string, data ResourceData) (interface{}, error)
+	// ResourceLogger and DataSourceLogger return loggers that write to the
+	// terraform-plugin-log logger of ctx, so their output follows TF_LOG.
+	ResourceLogger(ctx context.Context, resource, function string) zerolog.Logger
+	DataSourceLogger(ctx context.Context, datasource, function string) zerolog.Logger
 }
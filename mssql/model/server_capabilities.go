@@ -0,0 +1,56 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Engine editions reported by SERVERPROPERTY('EngineEdition').
+const (
+	EngineEditionPersonal          = 1
+	EngineEditionStandard          = 2
+	EngineEditionEnterprise        = 3
+	EngineEditionExpress           = 4
+	EngineEditionSQLDatabase       = 5
+	EngineEditionSynapse           = 6
+	EngineEditionManagedInstance   = 8
+	EngineEditionEdge              = 9
+	EngineEditionSynapseServerless = 11
+)
+
+// ServerCapabilities describes the engine of a server, as detected once per
+// run by the connector.
+type ServerCapabilities struct {
+	EngineEdition  int
+	ProductVersion string
+	Edition        string
+	// HadrEnabled is set when Always On availability groups are enabled.
+	HadrEnabled bool
+	// ContainedAG is set when the server hosts a contained availability group.
+	ContainedAG bool
+}
+
+// IsAzureSQLDatabase reports whether the server is an Azure SQL Database
+// logical server.
+func (c ServerCapabilities) IsAzureSQLDatabase() bool {
+	return c.EngineEdition == EngineEditionSQLDatabase
+}
+
+// IsManagedInstance reports whether the server is an Azure SQL Managed Instance.
+func (c ServerCapabilities) IsManagedInstance() bool {
+	return c.EngineEdition == EngineEditionManagedInstance
+}
+
+// IsAzure reports whether the server is an Azure SQL Database or Managed
+// Instance, i.e. whether @@VERSION starts with 'Microsoft SQL Azure'.
+func (c ServerCapabilities) IsAzure() bool {
+	return c.IsAzureSQLDatabase() || c.IsManagedInstance()
+}
+
+// MajorVersion returns the major product version, e.g. 16 for SQL Server
+// 2022, or 0 when it is unknown.
+func (c ServerCapabilities) MajorVersion() int {
+	major, _, _ := strings.Cut(c.ProductVersion, ".")
+	v, _ := strconv.Atoi(major)
+	return v
+}
@@ -0,0 +1,8 @@
+package model
+
+type ServerPermissions struct {
+	PrincipalName     string
+	PrincipalID       int
+	Permissions       []string
+	DeniedPermissions []string
+}
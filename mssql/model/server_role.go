@@ -0,0 +1,9 @@
+package model
+
+// ServerRole represents a SQL Server server role
+type ServerRole struct {
+	RoleID    int
+	RoleName  string
+	OwnerName string
+	OwnerId   int
+}
@@ -0,0 +1,10 @@
+package model
+
+// ServerRoleMember represents the membership of a login or server role in a
+// server role
+type ServerRoleMember struct {
+	RoleID     int
+	RoleName   string
+	MemberID   int
+	MemberName string
+}
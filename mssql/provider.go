@@ -3,21 +3,22 @@ package mssql
 import (
 	"context"
 	"fmt"
-	"io"
-	"os"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/validate"
 	"github.com/Jake-Barrow/terraform-provider-mssql/sql"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/rs/zerolog"
-	"github.com/rs/zerolog/log"
 )
 
 type mssqlProvider struct {
 	factory model.ConnectorFactory
-	logger  *zerolog.Logger
+	logFile zerolog.LevelWriter
 }
 
 const (
@@ -35,26 +36,195 @@ func New(version, commit string) func() *schema.Provider {
 }
 
 func Provider(factory model.ConnectorFactory) *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"debug": {
 				Type:        schema.TypeBool,
-				Description: fmt.Sprintf("Enable provider debug logging (logs to file %s)", providerLogFile),
+				Description: fmt.Sprintf("Write a debug log to %s, unless %s is set", providerLogFile, logPathProp),
 				Optional:    true,
 				Default:     false,
 			},
+			logPathProp: {
+				Type:        schema.TypeString,
+				Description: "Path of the provider log file, in addition to the Terraform log",
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MSSQL_LOG_PATH", ""),
+			},
+			logLevelProp: {
+				Type:         schema.TypeString,
+				Description:  "Minimum level of the entries written to the log file",
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("MSSQL_LOG_LEVEL", "debug"),
+				ValidateFunc: validation.StringInSlice([]string{"trace", "debug", "info", "warn", "error"}, false),
+			},
+			logFormatProp: {
+				Type:         schema.TypeString,
+				Description:  "Format of the log file, either json or console",
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("MSSQL_LOG_FORMAT", "json"),
+				ValidateFunc: validation.StringInSlice([]string{"json", "console"}, false),
+			},
+			logMaxSizeProp: {
+				Type:         schema.TypeInt,
+				Description:  "Size in megabytes after which the log file is rotated. 0 means no rotation",
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("MSSQL_LOG_MAX_SIZE", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			readOnlyProp: {
+				Type:        schema.TypeBool,
+				Description: "Fail every create, update and delete before any statement changing the server is sent. Reads and data sources keep working",
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MSSQL_READ_ONLY", false),
+			},
+			bulkReadProp: {
+				Type:        schema.TypeBool,
+				Description: "Read the principals, role memberships and permissions of each database once per run for the refresh of all its users and permissions",
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MSSQL_BULK_READ", false),
+			},
+			auditTableProp: {
+				Type:         schema.TypeString,
+				Description:  "Table, as [schema.]table, of each changed database that records the changes made by the provider. It is created if missing",
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("MSSQL_AUDIT_TABLE", ""),
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^([^.]+\.)?[^.]+$`), "must be [schema.]table"),
+			},
+			sqlOutputPathProp: {
+				Type:        schema.TypeString,
+				Description: "Write the statements that change the server to this file for review instead of executing them",
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MSSQL_SQL_OUTPUT_PATH", ""),
+			},
+			serverProp: {
+				Type:        schema.TypeList,
+				Description: "Default server for resources and data sources without a `server` block or `server_ref`",
+				MaxItems:    1,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: getServerSchema(serverProp),
+				},
+			},
+			maxOpenConnsProp: {
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of open connections per server and database. 0 means unlimited",
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			maxIdleConnsProp: {
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of idle connections kept per server and database",
+				Optional:     true,
+				Default:      2,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			tracingExporterProp: {
+				Type:         schema.TypeString,
+				Description:  "Exporter of OpenTelemetry traces: none, otlp, file or stdout",
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("MSSQL_TRACING_EXPORTER", "none"),
+				ValidateFunc: validation.StringInSlice([]string{"none", "otlp", "file", "stdout"}, false),
+			},
+			tracingEndpointProp: {
+				Type:        schema.TypeString,
+				Description: "URL of the OTLP/HTTP endpoint traces are exported to. Defaults to the OTEL_EXPORTER_OTLP_* environment variables",
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MSSQL_TRACING_ENDPOINT", ""),
+			},
+			tracingFileProp: {
+				Type:        schema.TypeString,
+				Description: "Path of the file traces are written to with the file exporter",
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MSSQL_TRACING_FILE", "terraform-provider-mssql-traces.json"),
+			},
+			maxDatabaseWritesProp: {
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of statements changing a database that the provider executes at the same time. 0 means unlimited",
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			writeLockProp: {
+				Type:        schema.TypeList,
+				Description: "Hold an exclusive application lock of the database, with sp_getapplock, while executing each statement that changes it",
+				MaxItems:    1,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						lockTimeoutProp: {
+							Type:         schema.TypeString,
+							Description:  "How long to wait for the lock before failing",
+							Optional:     true,
+							Default:      "30s",
+							ValidateFunc: validate.Duration,
+						},
+					},
+				},
+			},
+			retryProp: {
+				Type:        schema.TypeList,
+				Description: "Retry policy for transient errors when connecting and running idempotent statements",
+				MaxItems:    1,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						maxAttemptsProp: {
+							Type:         schema.TypeInt,
+							Description:  "Maximum number of attempts, including the first one",
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						backoffProp: {
+							Type:         schema.TypeString,
+							Description:  "Delay before the first retry, doubled after every attempt",
+							Optional:     true,
+							Default:      "1s",
+							ValidateFunc: validate.Duration,
+						},
+						maxBackoffProp: {
+							Type:         schema.TypeString,
+							Description:  "Maximum delay between two attempts",
+							Optional:     true,
+							Default:      "30s",
+							ValidateFunc: validate.Duration,
+						},
+						jitterProp: {
+							Type:         schema.TypeFloat,
+							Description:  "Fraction of each delay that is randomized",
+							Optional:     true,
+							Default:      0.2,
+							ValidateFunc: validation.FloatBetween(0, 1),
+						},
+					},
+				},
+			},
+			serverProfileProp: {
+				Type:        schema.TypeList,
+				Description: "Named servers that resources and data sources can select with `server_ref`",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: getServerProfileSchema(),
+				},
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"mssql_login": resourceLogin(),
 			"mssql_user": resourceUser(),
 			"mssql_database_permissions": resourceDatabasePermissions(),
 			"mssql_database_role": resourceDatabaseRole(),
+			"mssql_database_role_member": resourceDatabaseRoleMember(),
+			"mssql_application_role": resourceApplicationRole(),
 			"mssql_database_schema": resourceDatabaseSchema(),
 			"mssql_database_masterkey": resourceDatabaseMasterkey(),
 			"mssql_database_credential": resourceDatabaseCredential(),
 			"mssql_azure_external_datasource": resourceAzureExternalDatasource(),
 			"mssql_database_sqlscript": resourceDatabaseSQLScript(),
 			"mssql_entraid_login": resourceEntraIDLogin(),
+			"mssql_server_role": resourceServerRole(),
+			"mssql_server_role_member": resourceServerRoleMember(),
+			"mssql_server_permissions": resourceServerPermissions(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"mssql_login": dataSourceLogin(),
@@ -65,45 +235,125 @@ func Provider(factory model.ConnectorFactory) *schema.Provider {
 			"mssql_database_credential": datasourceDatabaseCredential(),
 			"mssql_azure_external_datasource": datasourceAzureExternalDatasource(),
 			"mssql_entraid_login": dataSourceEntraIDLogin(),
+			"mssql_server_role": dataSourceServerRole(),
 		},
 		ConfigureContextFunc: func(ctx context.Context, data *schema.ResourceData) (interface{}, diag.Diagnostics) {
 			return providerConfigure(ctx, data, factory)
 		},
 	}
+
+	for name, r := range p.ResourcesMap {
+		auditResource(r)
+		lockResource(r)
+		sqlOutputResource(r)
+		traceResource(name, r)
+	}
+	for name, r := range p.DataSourcesMap {
+		traceResource(name, r)
+	}
+
+	return p
 }
 
 func providerConfigure(ctx context.Context, data *schema.ResourceData, factory model.ConnectorFactory) (model.Provider, diag.Diagnostics) {
-	isDebug := data.Get("debug").(bool)
-	logger := newLogger(isDebug)
+	logPath := data.Get(logPathProp).(string)
+	if logPath == "" && data.Get("debug").(bool) {
+		logPath = providerLogFile
+	}
+	logFile, err := newLogFile(logPath, data.Get(logLevelProp).(string), data.Get(logFormatProp).(string), data.Get(logMaxSizeProp).(int))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	logger := newLogger(ctx, logFile)
+
+	if err := configureTracing(ctx, data.Get(tracingExporterProp).(string), data.Get(tracingEndpointProp).(string), data.Get(tracingFileProp).(string)); err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	config := &model.ProviderConfig{
+		ServerProfiles:    make(map[string]map[string]interface{}),
+		MaxOpenConns:      data.Get(maxOpenConnsProp).(int),
+		MaxIdleConns:      data.Get(maxIdleConnsProp).(int),
+		MaxDatabaseWrites: data.Get(maxDatabaseWritesProp).(int),
+		ReadOnly:          data.Get(readOnlyProp).(bool),
+		BulkRead:          data.Get(bulkReadProp).(bool),
+		AuditTable:        data.Get(auditTableProp).(string),
+	}
+	if path := data.Get(sqlOutputPathProp).(string); path != "" {
+		if config.SQLOutput, err = openSQLOutput(path); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		logger.Warn().Msgf("Writing statements to %s, changes are not applied to the server", path)
+	}
+	if logFile != nil {
+		// Statements are traced through terraform-plugin-log by the connector
+		// itself, so it only needs the log file.
+		fileLogger := zerolog.New(logFile).With().Timestamp().Logger()
+		config.Logger = &fileLogger
+	}
+	if server, ok := data.GetOk(serverProp + ".0"); ok {
+		config.Server = server.(map[string]interface{})
+	}
+	if lock, ok := data.GetOk(writeLockProp + ".0"); ok {
+		config.WriteLock = true
+		config.WriteLockTimeout, _ = time.ParseDuration(lock.(map[string]interface{})[lockTimeoutProp].(string))
+	}
+	if retry, ok := data.GetOk(retryProp + ".0"); ok {
+		policy := retry.(map[string]interface{})
+		config.Retry.MaxAttempts = policy[maxAttemptsProp].(int)
+		config.Retry.Backoff, _ = time.ParseDuration(policy[backoffProp].(string))
+		config.Retry.MaxBackoff, _ = time.ParseDuration(policy[maxBackoffProp].(string))
+		config.Retry.Jitter = policy[jitterProp].(float64)
+	}
+	for _, v := range data.Get(serverProfileProp).([]interface{}) {
+		profile := v.(map[string]interface{})
+		name := profile[nameProp].(string)
+		if _, ok := config.ServerProfiles[name]; ok {
+			return nil, diag.Errorf("server profile [%s] is defined more than once", name)
+		}
+		if countLoginMethods(profile) != 1 {
+			return nil, diag.Errorf("server profile [%s] must specify exactly one of %s", name, strings.Join(loginMethods, ", "))
+		}
+		if err := checkTLSSettings(profile); err != nil {
+			return nil, diag.Errorf("server profile [%s]: %v", name, err)
+		}
+		config.ServerProfiles[name] = profile
+	}
+
+	factory = factory.Configure(config)
+
+	// Pooled connections live as long as the provider. Terraform signals
+	// the provider to stop through the stop context; on a normal exit the
+	// connections are closed with the plugin process.
+	if stopCtx, ok := schema.StopContext(ctx); ok {
+		go func() {
+			<-stopCtx.Done()
+			if err := factory.Close(); err != nil {
+				logger.Err(err).Msg("error closing connections")
+			}
+			if err := flushTracing(context.Background()); err != nil {
+				logger.Err(err).Msg("error exporting traces")
+			}
+		}()
+	}
 
 	logger.Info().Msg("Created provider")
 
-	return mssqlProvider{factory: factory, logger: logger}, nil
+	return mssqlProvider{factory: factory, logFile: logFile}, nil
 }
 
-func (p mssqlProvider) GetConnector(prefix string, data *schema.ResourceData) (interface{}, error) {
-	return p.factory.GetConnector(prefix, data)
+func (p mssqlProvider) GetServer(prefix string, data model.ResourceData) (map[string]interface{}, error) {
+	return p.factory.GetServer(prefix, data)
 }
 
-func (p mssqlProvider) ResourceLogger(resource, function string) zerolog.Logger {
-	return p.logger.With().Str("resource", resource).Str("func", function).Logger()
+func (p mssqlProvider) GetConnector(prefix string, data model.ResourceData) (interface{}, error) {
+	return p.factory.GetConnector(prefix, data)
 }
 
-func (p mssqlProvider) DataSourceLogger(datasource, function string) zerolog.Logger {
-	return p.logger.With().Str("datasource", datasource).Str("func", function).Logger()
+func (p mssqlProvider) ResourceLogger(ctx context.Context, resource, function string) zerolog.Logger {
+	return newLogger(ctx, p.logFile).With().Str("resource", resource).Str("func", function).Logger()
 }
 
-func newLogger(isDebug bool) *zerolog.Logger {
-	var writer io.Writer = nil
-	logLevel := zerolog.Disabled
-	if isDebug {
-		f, err := os.OpenFile(providerLogFile, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
-		if err != nil {
-			log.Err(err).Msg("error opening file")
-		}
-		writer = f
-		logLevel = zerolog.DebugLevel
-	}
-	logger := zerolog.New(writer).Level(logLevel).With().Timestamp().Logger()
-	return &logger
+func (p mssqlProvider) DataSourceLogger(ctx context.Context, datasource, function string) zerolog.Logger {
+	return newLogger(ctx, p.logFile).With().Str("datasource", datasource).Str("func", function).Logger()
 }
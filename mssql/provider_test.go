@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
 	"text/template"
 	"time"
@@ -64,12 +65,18 @@ type TestConnector interface {
 	GetUser(database, name string) (*model.User, error)
 	GetDatabasePermissions(database, name string) (*model.DatabasePermissions, error)
 	GetDatabaseRole(database, name string) (*model.DatabaseRole, error)
+	GetDatabaseRoleMember(database, roleName, memberName string) (*model.DatabaseRoleMember, error)
+	GetApplicationRole(database, name string) (*model.ApplicationRole, error)
 	GetDatabaseSchema(database, name string) (*model.DatabaseSchema, error)
 	GetDatabaseCredential(database, name string) (*model.DatabaseCredential, error)
 	GetAzureExternalDatasource(database, name string) (*model.AzureExternalDatasource, error)
 	GetDatabaseMasterkey(database string) (*model.DatabaseMasterkey, error)
 	DataBaseExecuteScript(database string, sqlscript string) error
+	DataBaseVerifyObject(database string, query string) error
 	GetEntraIDLogin(name string) (*model.EntraIDLogin, error)
+	GetServerRole(name string) (*model.ServerRole, error)
+	GetServerRoleMember(roleName, memberName string) (*model.ServerRoleMember, error)
+	GetServerPermissions(name string) (*model.ServerPermissions, error)
 	GetSystemUser() (string, error)
 	GetCurrentUser(database string) (string, string, error)
 }
@@ -81,10 +88,26 @@ type testConnector struct {
 func getTestConnector(a map[string]string) (TestConnector, error) {
 	prefix := serverProp + ".0."
 
+	if _, ok := a[prefix+"host"]; !ok {
+		// The resource uses a provider server profile, so take the server from its ID
+		server, _, err := serverFromId(a["id"])
+		if err != nil {
+			return nil, err
+		}
+		a = flattenServer(server[0])
+	}
+
 	connector := &sql.Connector{
-		Host:    a[prefix+"host"],
-		Port:    a[prefix+"port"],
-		Timeout: 60 * time.Second,
+		Host:     a[prefix+"host"],
+		Port:     a[prefix+"port"],
+		Instance: a[prefix+"instance"],
+		Timeout:  60 * time.Second,
+		TLS: sql.TLSSettings{
+			Encrypt:                a[prefix+"encrypt"],
+			TrustServerCertificate: a[prefix+"trust_server_certificate"] == "true",
+			HostNameInCertificate:  a[prefix+"host_name_in_certificate"],
+			CACertificatePath:      a[prefix+"ca_certificate_path"],
+		},
 	}
 
 	if username, ok := a[prefix+"login.0.username"]; ok {
@@ -96,15 +119,34 @@ func getTestConnector(a map[string]string) (TestConnector, error) {
 
 	if tenantId, ok := a[prefix+"azure_login.0.tenant_id"]; ok {
 		connector.AzureLogin = &sql.AzureLogin{
-			TenantID:     tenantId,
-			ClientID:     a[prefix+"azure_login.0.client_id"],
-			ClientSecret: a[prefix+"azure_login.0.client_secret"],
+			TenantID:                  tenantId,
+			ClientID:                  a[prefix+"azure_login.0.client_id"],
+			ClientSecret:              a[prefix+"azure_login.0.client_secret"],
+			ClientCertificatePath:     a[prefix+"azure_login.0.client_certificate_path"],
+			ClientCertificatePassword: a[prefix+"azure_login.0.client_certificate_password"],
 		}
 	}
 
 	return testConnector{c: connector}, nil
 }
 
+func flattenServer(server map[string]interface{}) map[string]string {
+	prefix := serverProp + ".0."
+	a := map[string]string{
+		prefix + "host":     server["host"].(string),
+		prefix + "port":     server["port"].(string),
+		prefix + "instance": server["instance"].(string),
+	}
+	for _, method := range []string{"login", "azure_login"} {
+		if block, ok := server[method].([]map[string]interface{}); ok && len(block) > 0 {
+			for k, v := range block[0] {
+				a[prefix+method+".0."+k] = v.(string)
+			}
+		}
+	}
+	return a
+}
+
 func getTestLoginConnector(a map[string]string) (TestConnector, error) {
 	prefix := serverProp + ".0."
 	connector := &sql.Connector{
@@ -175,6 +217,14 @@ func (t testConnector) GetDatabaseRole(database string, roleName string) (*model
 	return t.c.(DatabaseRoleConnector).GetDatabaseRole(context.Background(), database, roleName)
 }
 
+func (t testConnector) GetDatabaseRoleMember(database, roleName, memberName string) (*model.DatabaseRoleMember, error) {
+	return t.c.(DatabaseRoleMemberConnector).GetDatabaseRoleMember(context.Background(), database, roleName, memberName)
+}
+
+func (t testConnector) GetApplicationRole(database, roleName string) (*model.ApplicationRole, error) {
+	return t.c.(ApplicationRoleConnector).GetApplicationRole(context.Background(), database, roleName)
+}
+
 func (t testConnector) GetDatabaseSchema(database string, schemaName string) (*model.DatabaseSchema, error) {
 	return t.c.(DatabaseSchemaConnector).GetDatabaseSchema(context.Background(), database, schemaName)
 }
@@ -195,10 +245,26 @@ func (t testConnector) DataBaseExecuteScript(database, sqlscript string) error {
 	return t.c.(DatabaseSQLScriptConnector).DataBaseExecuteScript(context.Background(), database, sqlscript)
 }
 
+func (t testConnector) DataBaseVerifyObject(database, query string) error {
+	return t.c.(DatabaseSQLScriptConnector).DataBaseVerifyObject(context.Background(), database, query)
+}
+
 func (t testConnector) GetEntraIDLogin(name string) (*model.EntraIDLogin, error) {
 	return t.c.(EntraIDLoginConnector).GetEntraIDLogin(context.Background(), name)
 }
 
+func (t testConnector) GetServerRole(name string) (*model.ServerRole, error) {
+	return t.c.(ServerRoleConnector).GetServerRole(context.Background(), name)
+}
+
+func (t testConnector) GetServerRoleMember(roleName, memberName string) (*model.ServerRoleMember, error) {
+	return t.c.(ServerRoleMemberConnector).GetServerRoleMember(context.Background(), roleName, memberName)
+}
+
+func (t testConnector) GetServerPermissions(name string) (*model.ServerPermissions, error) {
+	return t.c.(ServerPermissionsConnector).GetServerPermissions(context.Background(), name)
+}
+
 func (t testConnector) GetSystemUser() (string, error) {
 	var user string
 	err := t.c.(*sql.Connector).QueryRowContext(context.Background(), "SELECT SYSTEM_USER;", func(row *sql2.Row) error {
@@ -232,6 +298,10 @@ func templateToString(name, text string, data interface{}) (string, error) {
 }
 
 func testAccImportStateId(resource string, azure bool) func(state *terraform.State) (string, error) {
+	return testAccImportStateIdQuery(resource, "azure="+strconv.FormatBool(azure))
+}
+
+func testAccImportStateIdQuery(resource, query string) func(state *terraform.State) (string, error) {
 	return func(state *terraform.State) (string, error) {
 		rs, ok := state.RootModule().Resources[resource]
 		if !ok {
@@ -240,6 +310,10 @@ func testAccImportStateId(resource string, azure bool) func(state *terraform.Sta
 		if rs.Primary.ID == "" {
 			return "", fmt.Errorf("no record ID is set")
 		}
-		return rs.Primary.ID + "?azure=" + strconv.FormatBool(azure), nil
+		separator := "?"
+		if strings.Contains(rs.Primary.ID, "?") {
+			separator = "&"
+		}
+		return rs.Primary.ID + separator + query, nil
 	}
 }
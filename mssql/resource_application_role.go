@@ -0,0 +1,292 @@
+package mssql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceApplicationRole() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceApplicationRoleCreate,
+		ReadContext:   resourceApplicationRoleRead,
+		UpdateContext: resourceApplicationRoleUpdate,
+		DeleteContext: resourceApplicationRoleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceApplicationRoleImport,
+		},
+		Schema: map[string]*schema.Schema{
+			serverProp: {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: getServerSchema(serverProp),
+				},
+			},
+			serverRefProp: getServerRefSchema(),
+			databaseProp: {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  defaultDatabaseDefault,
+			},
+			roleNameProp: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.SQLIdentifier,
+			},
+			passwordProp: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validate.SQLIdentifierPassword,
+			},
+			defaultSchemaProp: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultDboPropDefault,
+				ValidateFunc: validate.SQLIdentifier,
+			},
+			principalIdProp: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: defaultTimeout,
+			Read:   defaultTimeout,
+			Update: defaultTimeout,
+			Delete: defaultTimeout,
+		},
+	}
+}
+
+type ApplicationRoleConnector interface {
+	CreateApplicationRole(ctx context.Context, database, roleName, password, defaultSchema string) error
+	GetApplicationRole(ctx context.Context, database, roleName string) (*model.ApplicationRole, error)
+	UpdateApplicationRole(ctx context.Context, database string, roleId int, roleName, password, defaultSchema string) error
+	DeleteApplicationRole(ctx context.Context, database, roleName string) error
+	DatabaseExists(ctx context.Context, database string) (bool, error)
+}
+
+func resourceApplicationRoleCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(ctx, meta, "application_role", "create")
+	id, err := getApplicationRoleID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debug().Msgf("Create %s", id)
+
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+	password := data.Get(passwordProp).(string)
+	defaultSchema := data.Get(defaultSchemaProp).(string)
+
+	connector, err := getApplicationRoleConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = connector.CreateApplicationRole(ctx, database, roleName, password, defaultSchema); err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to create application role [%s].[%s]", database, roleName))
+	}
+
+	data.SetId(id)
+
+	logger.Info().Msgf("created application role [%s].[%s]", database, roleName)
+
+	return resourceApplicationRoleRead(ctx, data, meta)
+}
+
+func resourceApplicationRoleRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(ctx, meta, "application_role", "read")
+	logger.Debug().Msgf("Read %s", data.Id())
+
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+
+	connector, err := getApplicationRoleConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Check if database exists
+	exists, err := connector.DatabaseExists(ctx, database)
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to check if database [%s] exists", database))
+	}
+	if !exists {
+		logger.Info().Msgf("Database [%s] does not exist", database)
+		data.SetId("")
+		return nil
+	}
+
+	role, err := connector.GetApplicationRole(ctx, database, roleName)
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to get application role [%s].[%s]", database, roleName))
+	}
+
+	if role == nil {
+		logger.Info().Msgf("application role [%s].[%s] does not exist", database, roleName)
+		data.SetId("")
+	} else {
+		if err = data.Set(principalIdProp, role.RoleID); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(roleNameProp, role.RoleName); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(defaultSchemaProp, role.DefaultSchema); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	logger.Info().Msgf("read application role [%s].[%s]", database, roleName)
+
+	return nil
+}
+
+func resourceApplicationRoleDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(ctx, meta, "application_role", "delete")
+	logger.Debug().Msgf("Delete %s", data.Id())
+
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+
+	connector, err := getApplicationRoleConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = connector.DeleteApplicationRole(ctx, database, roleName); err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to delete application role [%s].[%s]", database, roleName))
+	}
+
+	data.SetId("")
+
+	logger.Info().Msgf("deleted application role [%s].[%s]", database, roleName)
+
+	return nil
+}
+
+func resourceApplicationRoleUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(ctx, meta, "application_role", "update")
+	logger.Debug().Msgf("Update %s", data.Id())
+
+	database := data.Get(databaseProp).(string)
+	roleId := data.Get(principalIdProp).(int)
+	roleName := data.Get(roleNameProp).(string)
+
+	// Only send the password and default schema when they change, so the
+	// password is not rotated on a rename
+	var password, defaultSchema string
+	if data.HasChange(passwordProp) {
+		password = data.Get(passwordProp).(string)
+	}
+	if data.HasChange(defaultSchemaProp) {
+		defaultSchema = data.Get(defaultSchemaProp).(string)
+	}
+
+	// Store old values for all properties that might change
+	oldValues := make(map[string]interface{})
+	for _, prop := range []string{roleNameProp, passwordProp, defaultSchemaProp} {
+		if data.HasChange(prop) {
+			oldValue, _ := data.GetChange(prop)
+			oldValues[prop] = oldValue
+		}
+	}
+
+	connector, err := getApplicationRoleConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = connector.UpdateApplicationRole(ctx, database, roleId, roleName, password, defaultSchema); err != nil {
+		// If update fails, revert all changed values in the state
+		for prop, oldValue := range oldValues {
+			if err := data.Set(prop, oldValue); err != nil {
+				logger.Error().Err(err).Msgf("Failed to revert %s state after update error", prop)
+			}
+		}
+		return diag.FromErr(errors.Wrapf(err, "unable to update application role [%s].[%s]", database, roleName))
+	}
+
+	id, err := getApplicationRoleID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(id)
+
+	logger.Info().Msgf("updated application role [%s].[%s]", database, roleName)
+
+	return resourceApplicationRoleRead(ctx, data, meta)
+}
+
+func resourceApplicationRoleImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	logger := loggerFromMeta(ctx, meta, "application_role", "import")
+	logger.Debug().Msgf("Import %s", data.Id())
+
+	u, err := setServerFromId(meta, data)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(u.Path, "/")
+	if len(parts) != 4 || parts[2] != "application_role" {
+		return nil, errors.New("invalid ID")
+	}
+	if err = data.Set(databaseProp, parts[1]); err != nil {
+		return nil, err
+	}
+	if err = data.Set(roleNameProp, parts[3]); err != nil {
+		return nil, err
+	}
+
+	id, err := getApplicationRoleID(meta, data)
+	if err != nil {
+		return nil, err
+	}
+	data.SetId(id)
+
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+
+	connector, err := getApplicationRoleConnector(meta, data)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := connector.GetApplicationRole(ctx, database, roleName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get application role [%s].[%s]", database, roleName)
+	}
+
+	if role == nil {
+		return nil, errors.Errorf("application role [%s].[%s] does not exist", database, roleName)
+	}
+
+	if err = data.Set(principalIdProp, role.RoleID); err != nil {
+		return nil, err
+	}
+	if err = data.Set(defaultSchemaProp, role.DefaultSchema); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{data}, nil
+}
+
+func getApplicationRoleConnector(meta interface{}, data *schema.ResourceData) (ApplicationRoleConnector, error) {
+	provider := meta.(model.Provider)
+	connector, err := provider.GetConnector(serverProp, data)
+	if err != nil {
+		return nil, err
+	}
+	return connector.(ApplicationRoleConnector), nil
+}
@@ -0,0 +1,32 @@
+package mssql
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccApplicationRole_Local_BasicImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckApplicationRoleDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckApplicationRole(t, "test_import", "login", map[string]interface{}{"role_name": "test_app_role_import", "password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckApplicationRoleExists("mssql_application_role.test_import"),
+				),
+			},
+			{
+				ResourceName:            "mssql_application_role.test_import",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testAccImportStateId("mssql_application_role.test_import", false),
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
@@ -0,0 +1,165 @@
+package mssql
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccApplicationRole_Local_Basic_Create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckApplicationRoleDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckApplicationRole(t, "local_test_create", "login", map[string]interface{}{"role_name": "test_app_role_create", "password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckApplicationRoleExists("mssql_application_role.local_test_create", Check{"default_schema", "==", "dbo"}),
+					resource.TestCheckResourceAttr("mssql_application_role.local_test_create", "id", "sqlserver://localhost:1433/master/application_role/test_app_role_create"),
+					resource.TestCheckResourceAttr("mssql_application_role.local_test_create", "database", "master"),
+					resource.TestCheckResourceAttr("mssql_application_role.local_test_create", "role_name", "test_app_role_create"),
+					resource.TestCheckResourceAttr("mssql_application_role.local_test_create", "default_schema", "dbo"),
+					resource.TestCheckResourceAttr("mssql_application_role.local_test_create", "password", "valueIsH8kd$¡"),
+					resource.TestCheckResourceAttr("mssql_application_role.local_test_create", "server.#", "1"),
+					resource.TestCheckResourceAttr("mssql_application_role.local_test_create", "server.0.host", "localhost"),
+					resource.TestCheckResourceAttr("mssql_application_role.local_test_create", "server.0.port", "1433"),
+					resource.TestCheckResourceAttr("mssql_application_role.local_test_create", "server.0.login.#", "1"),
+					resource.TestCheckResourceAttr("mssql_application_role.local_test_create", "server.0.login.0.username", os.Getenv("MSSQL_USERNAME")),
+					resource.TestCheckResourceAttr("mssql_application_role.local_test_create", "server.0.login.0.password", os.Getenv("MSSQL_PASSWORD")),
+					resource.TestCheckResourceAttrSet("mssql_application_role.local_test_create", "principal_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccApplicationRole_Local_Basic_Update(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckApplicationRoleDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckApplicationRole(t, "local_test_update", "login", map[string]interface{}{"role_name": "test_app_role_pre", "password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckApplicationRoleExists("mssql_application_role.local_test_update", Check{"role_name", "==", "test_app_role_pre"}),
+					resource.TestCheckResourceAttr("mssql_application_role.local_test_update", "role_name", "test_app_role_pre"),
+				),
+			},
+			{
+				Config: testAccCheckApplicationRole(t, "local_test_update", "login", map[string]interface{}{"role_name": "test_app_role_post", "password": "otherIsH8kd$¡", "default_schema": "sys"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckApplicationRoleExists("mssql_application_role.local_test_update", Check{"role_name", "==", "test_app_role_post"}, Check{"default_schema", "==", "sys"}),
+					resource.TestCheckResourceAttr("mssql_application_role.local_test_update", "role_name", "test_app_role_post"),
+					resource.TestCheckResourceAttr("mssql_application_role.local_test_update", "password", "otherIsH8kd$¡"),
+					resource.TestCheckResourceAttr("mssql_application_role.local_test_update", "default_schema", "sys"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckApplicationRole(t *testing.T, name string, login string, data map[string]interface{}) string {
+	text := `resource "mssql_application_role" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				{{ with .database }}database = "{{ . }}"{{ end }}
+				role_name = "{{ .role_name }}"
+				password  = "{{ .password }}"
+				{{ with .default_schema }}default_schema = "{{ . }}"{{ end }}
+			}`
+
+	data["name"] = name
+	data["login"] = login
+	if login == "fedauth" || login == "msi" || login == "azure" {
+		data["host"] = os.Getenv("TF_ACC_SQL_SERVER")
+	} else if login == "login" {
+		data["host"] = "localhost"
+	} else {
+		t.Fatalf("login expected to be one of 'login', 'azure', 'msi', 'fedauth', got %s", login)
+	}
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return res
+}
+
+func testAccCheckApplicationRoleDestroy(state *terraform.State) error {
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "mssql_application_role" {
+			continue
+		}
+
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+
+		database := rs.Primary.Attributes["database"]
+		roleName := rs.Primary.Attributes["role_name"]
+		role, err := connector.GetApplicationRole(database, roleName)
+		if role != nil {
+			return fmt.Errorf("application role still exists")
+		}
+		if err != nil {
+			return fmt.Errorf("expected no error, got %s", err)
+		}
+	}
+	return nil
+}
+
+func testAccCheckApplicationRoleExists(resource string, checks ...Check) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("not found: %s", resource)
+		}
+		if rs.Type != "mssql_application_role" {
+			return fmt.Errorf("expected resource of type %s, got %s", "mssql_application_role", rs.Type)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no record ID is set")
+		}
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+		database := rs.Primary.Attributes["database"]
+		roleName := rs.Primary.Attributes["role_name"]
+		role, err := connector.GetApplicationRole(database, roleName)
+		if err != nil {
+			return fmt.Errorf("error: %s", err)
+		}
+		if role == nil {
+			return fmt.Errorf("application role %s does not exist", roleName)
+		}
+
+		var actual interface{}
+		for _, check := range checks {
+			switch check.name {
+			case "role_name":
+				actual = role.RoleName
+			case "default_schema":
+				actual = role.DefaultSchema
+			default:
+				return fmt.Errorf("unknown property %s", check.name)
+			}
+			if (check.op == "" || check.op == "==") && !equal(check.expected, actual) {
+				return fmt.Errorf("expected %s == %s, got %s", check.name, check.expected, actual)
+			}
+			if check.op == "!=" && equal(check.expected, actual) {
+				return fmt.Errorf("expected %s != %s, got %s", check.name, check.expected, actual)
+			}
+		}
+		return nil
+	}
+}
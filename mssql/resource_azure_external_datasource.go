@@ -26,12 +26,13 @@ func resourceAzureExternalDatasource() *schema.Resource {
 			serverProp: {
 				Type:     schema.TypeList,
 				MaxItems: 1,
-				Required: true,
+				Optional: true,
 				ForceNew: true,
 				Elem: &schema.Resource{
 					Schema: getServerSchema(serverProp),
 				},
 			},
+			serverRefProp: getServerRefSchema(),
 			databaseProp: {
 				Type:     schema.TypeString,
 				Required: true,
@@ -94,7 +95,7 @@ func resourceAzureExternalDatasource() *schema.Resource {
 }
 
 type AzureExternalDatasourceConnector interface {
-	GetMSSQLVersion(ctx context.Context) (string, error)
+	GetServerCapabilities(ctx context.Context) (*model.ServerCapabilities, error)
 	CreateAzureExternalDatasource(ctx context.Context, database, datasourcename, location, credentialname, typestr, rdatabasename string) error
 	GetAzureExternalDatasource(ctx context.Context, database, datasourcename string) (*model.AzureExternalDatasource, error)
 	UpdateAzureExternalDatasource(ctx context.Context, database, datasourcename, location, credentialname, rdatabasename string) error
@@ -103,8 +104,12 @@ type AzureExternalDatasourceConnector interface {
 }
 
 func resourceAzureExternalDatasourceCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "azureexternaldatasource", "create")
-	logger.Debug().Msgf("Create %s", getAzureExternalDatasourceID(data))
+	logger := loggerFromMeta(ctx, meta, "azureexternaldatasource", "create")
+	id, err := getAzureExternalDatasourceID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debug().Msgf("Create %s", id)
 
 	database := data.Get(databaseProp).(string)
 	datasourcename := data.Get(datasourcenameProp).(string)
@@ -118,11 +123,11 @@ func resourceAzureExternalDatasourceCreate(ctx context.Context, data *schema.Res
 		return diag.FromErr(err)
 	}
 
-	mssqlversion, err := connector.GetMSSQLVersion(ctx)
+	caps, err := connector.GetServerCapabilities(ctx)
 	if err != nil {
-		return diag.FromErr(errors.Wrap(err, "unable to get MSSQL version"))
+		return diag.FromErr(errors.Wrap(err, "unable to detect server capabilities"))
 	}
-	if !strings.Contains(mssqlversion, "Microsoft SQL Azure") {
+	if !caps.IsAzure() {
 		return diag.Errorf("The database is not an Azure SQL Database.")
 	}
 
@@ -130,7 +135,7 @@ func resourceAzureExternalDatasourceCreate(ctx context.Context, data *schema.Res
 		return diag.FromErr(errors.Wrapf(err, "unable to create external data source [%s] on database [%s]", datasourcename, database))
 	}
 
-	data.SetId(getAzureExternalDatasourceID(data))
+	data.SetId(id)
 
 	logger.Info().Msgf("created external data source [%s] on database [%s]", datasourcename, database)
 
@@ -138,7 +143,7 @@ func resourceAzureExternalDatasourceCreate(ctx context.Context, data *schema.Res
 }
 
 func resourceAzureExternalDatasourceRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "azureexternaldatasource", "read")
+	logger := loggerFromMeta(ctx, meta, "azureexternaldatasource", "read")
 	logger.Debug().Msgf("Read %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -160,11 +165,11 @@ func resourceAzureExternalDatasourceRead(ctx context.Context, data *schema.Resou
 		return nil
 	}
 
-	mssqlversion, err := connector.GetMSSQLVersion(ctx)
+	caps, err := connector.GetServerCapabilities(ctx)
 	if err != nil {
-		return diag.FromErr(errors.Wrap(err, "unable to get MSSQL version"))
+		return diag.FromErr(errors.Wrap(err, "unable to detect server capabilities"))
 	}
-	if !strings.Contains(mssqlversion, "Microsoft SQL Azure") {
+	if !caps.IsAzure() {
 		return diag.Errorf("The database is not an Azure SQL Database.")
 	}
 
@@ -203,7 +208,7 @@ func resourceAzureExternalDatasourceRead(ctx context.Context, data *schema.Resou
 }
 
 func resourceAzureExternalDatasourceUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "azureexternaldatasource", "update")
+	logger := loggerFromMeta(ctx, meta, "azureexternaldatasource", "update")
 	logger.Debug().Msgf("Update %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -236,7 +241,11 @@ func resourceAzureExternalDatasourceUpdate(ctx context.Context, data *schema.Res
 		return diag.FromErr(errors.Wrapf(err, "unable to update external data source [%s] on database [%s]", datasourcename, database))
 	}
 
-	data.SetId(getAzureExternalDatasourceID(data))
+	id, err := getAzureExternalDatasourceID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(id)
 
 	logger.Info().Msgf("updated external data source [%s] on database [%s]", datasourcename, database)
 
@@ -244,7 +253,7 @@ func resourceAzureExternalDatasourceUpdate(ctx context.Context, data *schema.Res
 }
 
 func resourceAzureExternalDatasourceDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "azureexternaldatasource", "delete")
+	logger := loggerFromMeta(ctx, meta, "azureexternaldatasource", "delete")
 	logger.Debug().Msgf("Delete %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -267,16 +276,13 @@ func resourceAzureExternalDatasourceDelete(ctx context.Context, data *schema.Res
 }
 
 func resourceAzureExternalDatasourceImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	logger := loggerFromMeta(meta, "azureexternaldatasource", "import")
+	logger := loggerFromMeta(ctx, meta, "azureexternaldatasource", "import")
 	logger.Debug().Msgf("Import %s", data.Id())
 
-	server, u, err := serverFromId(data.Id())
+	u, err := setServerFromId(meta, data)
 	if err != nil {
 		return nil, err
 	}
-	if err := data.Set(serverProp, server); err != nil {
-		return nil, err
-	}
 
 	parts := strings.Split(u.Path, "/")
 	if len(parts) != 4 {
@@ -289,7 +295,11 @@ func resourceAzureExternalDatasourceImport(ctx context.Context, data *schema.Res
 		return nil, err
 	}
 
-	data.SetId(getAzureExternalDatasourceID(data))
+	id, err := getAzureExternalDatasourceID(meta, data)
+	if err != nil {
+		return nil, err
+	}
+	data.SetId(id)
 
 	database := data.Get(databaseProp).(string)
 	datasourcename := data.Get(datasourcenameProp).(string)
@@ -299,12 +309,12 @@ func resourceAzureExternalDatasourceImport(ctx context.Context, data *schema.Res
 		return nil, err
 	}
 
-	mssqlversion, err := connector.GetMSSQLVersion(ctx)
+	caps, err := connector.GetServerCapabilities(ctx)
 	if err != nil {
-		return nil, errors.Wrapf(err, "unable to get MSSQL version")
+		return nil, errors.Wrap(err, "unable to detect server capabilities")
 	}
-	if !strings.Contains(mssqlversion, "Microsoft SQL Azure") {
-		return nil, errors.Wrapf(err, "The database is not an Azure SQL Database.")
+	if !caps.IsAzure() {
+		return nil, errors.New("The database is not an Azure SQL Database.")
 	}
 
 	extdatasource, err := connector.GetAzureExternalDatasource(ctx, database, datasourcename)
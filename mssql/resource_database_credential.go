@@ -24,12 +24,13 @@ func resourceDatabaseCredential() *schema.Resource {
 			serverProp: {
 				Type:     schema.TypeList,
 				MaxItems: 1,
-				Required: true,
+				Optional: true,
 				ForceNew: true,
 				Elem: &schema.Resource{
 					Schema: getServerSchema(serverProp),
 				},
 			},
+			serverRefProp: getServerRefSchema(),
 			databaseProp: {
 				Type:     schema.TypeString,
 				Required: true,
@@ -79,8 +80,12 @@ type DatabaseCredentialConnector interface {
 }
 
 func resourceDatabaseCredentialCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "databasecredential", "create")
-	logger.Debug().Msgf("Create %s", getDatabaseCredentialID(data))
+	logger := loggerFromMeta(ctx, meta, "databasecredential", "create")
+	id, err := getDatabaseCredentialID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debug().Msgf("Create %s", id)
 
 	database := data.Get(databaseProp).(string)
 	credentialname := data.Get(credentialNameProp).(string)
@@ -96,7 +101,7 @@ func resourceDatabaseCredentialCreate(ctx context.Context, data *schema.Resource
 		return diag.FromErr(errors.Wrapf(err, "unable to create database scoped credential [%s] on database [%s]", credentialname, database))
 	}
 
-	data.SetId(getDatabaseCredentialID(data))
+	data.SetId(id)
 
 	logger.Info().Msgf("created database scoped credential [%s] on database [%s]", credentialname, database)
 
@@ -104,7 +109,7 @@ func resourceDatabaseCredentialCreate(ctx context.Context, data *schema.Resource
 }
 
 func resourceDatabaseCredentialRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "databasecredential", "read")
+	logger := loggerFromMeta(ctx, meta, "databasecredential", "read")
 	logger.Debug().Msgf("Read %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -152,7 +157,7 @@ func resourceDatabaseCredentialRead(ctx context.Context, data *schema.ResourceDa
 }
 
 func resourceDatabaseCredentialUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "databasecredential", "update")
+	logger := loggerFromMeta(ctx, meta, "databasecredential", "update")
 	logger.Debug().Msgf("Update %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -184,7 +189,11 @@ func resourceDatabaseCredentialUpdate(ctx context.Context, data *schema.Resource
 		return diag.FromErr(errors.Wrapf(err, "unable to update database scoped credential [%s] on database [%s]", credentialname, database))
 	}
 
-	data.SetId(getDatabaseCredentialID(data))
+	id, err := getDatabaseCredentialID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(id)
 
 	logger.Info().Msgf("updated database scoped credential [%s] on database [%s]", credentialname, database)
 
@@ -192,7 +201,7 @@ func resourceDatabaseCredentialUpdate(ctx context.Context, data *schema.Resource
 }
 
 func resourceDatabaseCredentialDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "databasecredential", "delete")
+	logger := loggerFromMeta(ctx, meta, "databasecredential", "delete")
 	logger.Debug().Msgf("Delete %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -215,16 +224,13 @@ func resourceDatabaseCredentialDelete(ctx context.Context, data *schema.Resource
 }
 
 func resourceDatabaseCredentialImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	logger := loggerFromMeta(meta, "databasecredential", "import")
+	logger := loggerFromMeta(ctx, meta, "databasecredential", "import")
 	logger.Debug().Msgf("Import %s", data.Id())
 
-	server, u, err := serverFromId(data.Id())
+	u, err := setServerFromId(meta, data)
 	if err != nil {
 		return nil, err
 	}
-	if err := data.Set(serverProp, server); err != nil {
-		return nil, err
-	}
 
 	parts := strings.Split(u.Path, "/")
 	if len(parts) != 4 {
@@ -237,7 +243,11 @@ func resourceDatabaseCredentialImport(ctx context.Context, data *schema.Resource
 		return nil, err
 	}
 
-	data.SetId(getDatabaseCredentialID(data))
+	id, err := getDatabaseCredentialID(meta, data)
+	if err != nil {
+		return nil, err
+	}
+	data.SetId(id)
 
 	database := data.Get(databaseProp).(string)
 	credentialname := data.Get(credentialNameProp).(string)
@@ -20,12 +20,13 @@ func resourceDatabaseMasterkey() *schema.Resource {
 			serverProp: {
 				Type:     schema.TypeList,
 				MaxItems: 1,
-				Required: true,
+				Optional: true,
 				ForceNew: true,
 				Elem: &schema.Resource{
 					Schema: getServerSchema(serverProp),
 				},
 			},
+			serverRefProp: getServerRefSchema(),
 			databaseProp: {
 				Type:     schema.TypeString,
 				Required: true,
@@ -84,8 +85,12 @@ type DatabaseMasterkeyConnector interface {
 }
 
 func resourceDatabaseMasterkeyCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "databasemasterkey", "create")
-	logger.Debug().Msgf("Create %s", getDatabaseMasterkeyID(data))
+	logger := loggerFromMeta(ctx, meta, "databasemasterkey", "create")
+	id, err := getDatabaseMasterkeyID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debug().Msgf("Create %s", id)
 
 	database := data.Get(databaseProp).(string)
 	password := data.Get(passwordProp).(string)
@@ -99,7 +104,7 @@ func resourceDatabaseMasterkeyCreate(ctx context.Context, data *schema.ResourceD
 		return diag.FromErr(errors.Wrapf(err, "unable to create database master key on database [%s]", database))
 	}
 
-	data.SetId(getDatabaseMasterkeyID(data))
+	data.SetId(id)
 
 	logger.Info().Msgf("created database master key on database [%s]", database)
 
@@ -107,7 +112,7 @@ func resourceDatabaseMasterkeyCreate(ctx context.Context, data *schema.ResourceD
 }
 
 func resourceDatabaseMasterkeyRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "databasemasterkey", "read")
+	logger := loggerFromMeta(ctx, meta, "databasemasterkey", "read")
 	logger.Debug().Msgf("Read %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -164,7 +169,7 @@ func resourceDatabaseMasterkeyRead(ctx context.Context, data *schema.ResourceDat
 }
 
 func resourceDatabaseMasterkeyUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "databasemasterkey", "update")
+	logger := loggerFromMeta(ctx, meta, "databasemasterkey", "update")
 	logger.Debug().Msgf("Update %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -194,7 +199,11 @@ func resourceDatabaseMasterkeyUpdate(ctx context.Context, data *schema.ResourceD
 		return diag.FromErr(errors.Wrapf(err, "unable to update database key on database [%s]", database))
 	}
 
-	data.SetId(getDatabaseMasterkeyID(data))
+	id, err := getDatabaseMasterkeyID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(id)
 
 	logger.Info().Msgf("updated database master key on database [%s]", database)
 
@@ -202,7 +211,7 @@ func resourceDatabaseMasterkeyUpdate(ctx context.Context, data *schema.ResourceD
 }
 
 func resourceDatabaseMasterkeyDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "databasemasterkey", "delete")
+	logger := loggerFromMeta(ctx, meta, "databasemasterkey", "delete")
 	logger.Debug().Msgf("Delete %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
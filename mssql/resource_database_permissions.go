@@ -25,12 +25,13 @@ func resourceDatabasePermissions() *schema.Resource {
 			serverProp: {
 				Type:     schema.TypeList,
 				MaxItems: 1,
-				Required: true,
+				Optional: true,
 				ForceNew: true,
 				Elem: &schema.Resource{
 					Schema: getServerSchema(serverProp),
 				},
 			},
+			serverRefProp: getServerRefSchema(),
 			databaseProp: {
 				Type:     schema.TypeString,
 				Required: true,
@@ -72,8 +73,12 @@ type DatabasePermissionsConnector interface {
 }
 
 func resourceDatabasePermissionsCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "databasepermissions", "create")
-	logger.Debug().Msgf("Create %s", getDatabasePermissionsID(data))
+	logger := loggerFromMeta(ctx, meta, "databasepermissions", "create")
+	id, err := getDatabasePermissionsID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debug().Msgf("Create %s", id)
 
 	database := data.Get(databaseProp).(string)
 	username := data.Get(usernameProp).(string)
@@ -94,7 +99,7 @@ func resourceDatabasePermissionsCreate(ctx context.Context, data *schema.Resourc
 		return diag.FromErr(errors.Wrapf(err, "unable to create database permissions %v on database [%s] for user [%s]", string(permissions_), database, username))
 	}
 
-	data.SetId(getDatabasePermissionsID(data))
+	data.SetId(id)
 
 	logger.Info().Msgf("created database permissions %v on database [%s] for user [%s]", string(permissions_), database, username)
 
@@ -102,7 +107,7 @@ func resourceDatabasePermissionsCreate(ctx context.Context, data *schema.Resourc
 }
 
 func resourceDatabasePermissionsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "databasepermissions", "read")
+	logger := loggerFromMeta(ctx, meta, "databasepermissions", "read")
 	logger.Debug().Msgf("Read %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -150,7 +155,7 @@ func resourceDatabasePermissionsRead(ctx context.Context, data *schema.ResourceD
 }
 
 func resourceDatabasePermissionDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "databasepermissions", "delete")
+	logger := loggerFromMeta(ctx, meta, "databasepermissions", "delete")
 	logger.Debug().Msgf("Delete %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -194,7 +199,7 @@ func resourceDatabasePermissionDelete(ctx context.Context, data *schema.Resource
 }
 
 func resourceDatabasePermissionUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "databasepermissions", "update")
+	logger := loggerFromMeta(ctx, meta, "databasepermissions", "update")
 	logger.Debug().Msgf("Update %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -233,7 +238,11 @@ func resourceDatabasePermissionUpdate(ctx context.Context, data *schema.Resource
 		return diag.FromErr(errors.Wrapf(err, "unable to update permissions for user [%s] on database [%s]", username, database))
 	}
 
-	data.SetId(getDatabasePermissionsID(data))
+	id, err := getDatabasePermissionsID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(id)
 
 	logger.Info().Msgf("updated permissions for user [%s] on database [%s]", username, database)
 
@@ -241,16 +250,13 @@ func resourceDatabasePermissionUpdate(ctx context.Context, data *schema.Resource
 }
 
 func resourceDatabasePermissionImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	logger := loggerFromMeta(meta, "databasepermissions", "import")
+	logger := loggerFromMeta(ctx, meta, "databasepermissions", "import")
 	logger.Debug().Msgf("Import %s", data.Id())
 
-	server, u, err := serverFromId(data.Id())
+	u, err := setServerFromId(meta, data)
 	if err != nil {
 		return nil, err
 	}
-	if err = data.Set(serverProp, server); err != nil {
-		return nil, err
-	}
 
 	parts := strings.Split(u.Path, "/")
 	if len(parts) != 4 {
@@ -267,7 +273,11 @@ func resourceDatabasePermissionImport(ctx context.Context, data *schema.Resource
 	database := data.Get(databaseProp).(string)
 	username := data.Get(usernameProp).(string)
 
-	data.SetId(getDatabasePermissionsID(data))
+	id, err := getDatabasePermissionsID(meta, data)
+	if err != nil {
+		return nil, err
+	}
+	data.SetId(id)
 
 	connector, err := getDatabasePermissionsConnector(meta, data)
 	if err != nil {
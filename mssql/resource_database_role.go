@@ -24,11 +24,12 @@ func resourceDatabaseRole() *schema.Resource {
 			serverProp: {
 				Type:     schema.TypeList,
 				MaxItems: 1,
-				Required: true,
+				Optional: true,
 				Elem: &schema.Resource{
 					Schema: getServerSchema(serverProp),
 				},
 			},
+			serverRefProp: getServerRefSchema(),
 			databaseProp: {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -56,6 +57,13 @@ func resourceDatabaseRole() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			membersProp: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: defaultTimeout,
@@ -71,12 +79,19 @@ type DatabaseRoleConnector interface {
 	GetDatabaseRole(ctx context.Context, database, roleName string) (*model.DatabaseRole, error)
 	UpdateDatabaseRole(ctx context.Context, database string, roleId int, roleName string, ownerName string) error
 	DeleteDatabaseRole(ctx context.Context, database, roleName string) error
+	UpdateDatabaseRoleMembers(ctx context.Context, database, roleName string, members []string) error
+	AddDatabaseRoleMember(ctx context.Context, database, roleName, memberName string) error
+	DropDatabaseRoleMember(ctx context.Context, database, roleName, memberName string) error
 	DatabaseExists(ctx context.Context, database string) (bool, error)
 }
 
 func resourceDatabaseRoleCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "role", "create")
-	logger.Debug().Msgf("Create %s", getDatabaseRoleID(data))
+	logger := loggerFromMeta(ctx, meta, "role", "create")
+	id, err := getDatabaseRoleID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debug().Msgf("Create %s", id)
 
 	database := data.Get(databaseProp).(string)
 	roleName := data.Get(roleNameProp).(string)
@@ -91,7 +106,13 @@ func resourceDatabaseRoleCreate(ctx context.Context, data *schema.ResourceData,
 		return diag.FromErr(errors.Wrapf(err, "unable to create role [%s].[%s]", database, roleName))
 	}
 
-	data.SetId(getDatabaseRoleID(data))
+	data.SetId(id)
+
+	if members, ok := data.GetOk(membersProp); ok {
+		if err = connector.UpdateDatabaseRoleMembers(ctx, database, roleName, toStringSlice(members.(*schema.Set).List())); err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to set members of role [%s].[%s]", database, roleName))
+		}
+	}
 
 	logger.Info().Msgf("created role [%s].[%s]", database, roleName)
 
@@ -99,7 +120,7 @@ func resourceDatabaseRoleCreate(ctx context.Context, data *schema.ResourceData,
 }
 
 func resourceDatabaseRoleRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "role", "read")
+	logger := loggerFromMeta(ctx, meta, "role", "read")
 	logger.Debug().Msgf("Read %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -142,6 +163,13 @@ func resourceDatabaseRoleRead(ctx context.Context, data *schema.ResourceData, me
 		if err = data.Set(ownerIdProp, role.OwnerId); err != nil {
 			return diag.FromErr(err)
 		}
+		// Only refresh the members when they are managed by the resource, so
+		// memberships managed by other resources do not show up as a diff
+		if data.Get(membersProp).(*schema.Set).Len() > 0 {
+			if err = data.Set(membersProp, role.Members); err != nil {
+				return diag.FromErr(err)
+			}
+		}
 	}
 
 	logger.Info().Msgf("read role [%s].[%s]", database, roleName)
@@ -150,7 +178,7 @@ func resourceDatabaseRoleRead(ctx context.Context, data *schema.ResourceData, me
 }
 
 func resourceDatabaseRoleDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "role", "delete")
+	logger := loggerFromMeta(ctx, meta, "role", "delete")
 	logger.Debug().Msgf("Delete %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -161,6 +189,14 @@ func resourceDatabaseRoleDelete(ctx context.Context, data *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	// A role can only be dropped once it has no members. Members that are not
+	// managed by the resource are dropped by their own resources first
+	if data.Get(membersProp).(*schema.Set).Len() > 0 {
+		if err = connector.UpdateDatabaseRoleMembers(ctx, database, roleName, []string{}); err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to drop the members of role [%s].[%s]", database, roleName))
+		}
+	}
+
 	if err = connector.DeleteDatabaseRole(ctx, database, roleName); err != nil {
 		return diag.FromErr(errors.Wrapf(err, "unable to delete role [%s].[%s]", database, roleName))
 	}
@@ -173,7 +209,7 @@ func resourceDatabaseRoleDelete(ctx context.Context, data *schema.ResourceData,
 }
 
 func resourceDatabaseRoleUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "role", "update")
+	logger := loggerFromMeta(ctx, meta, "role", "update")
 	logger.Debug().Msgf("Update %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -183,9 +219,12 @@ func resourceDatabaseRoleUpdate(ctx context.Context, data *schema.ResourceData,
 
 	// Store old values for all properties that might change
 	oldValues := make(map[string]interface{})
-	for _, prop := range []string{roleNameProp, ownerNameProp} {
+	for _, prop := range []string{roleNameProp, ownerNameProp, membersProp} {
 		if data.HasChange(prop) {
 			oldValue, _ := data.GetChange(prop)
+			if oldSet, ok := oldValue.(*schema.Set); ok {
+				oldValue = oldSet.List()
+			}
 			oldValues[prop] = oldValue
 		}
 	}
@@ -195,7 +234,16 @@ func resourceDatabaseRoleUpdate(ctx context.Context, data *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	if err = connector.UpdateDatabaseRole(ctx, database, roleId, roleName, ownerName); err != nil {
+	if data.HasChanges(roleNameProp, ownerNameProp) {
+		err = connector.UpdateDatabaseRole(ctx, database, roleId, roleName, ownerName)
+	}
+	// Members are left as they are once members is removed from the
+	// configuration, so only an explicitly configured set is applied
+	if err == nil && data.HasChange(membersProp) && !isNullInConfig(data, membersProp) {
+		oldMembers, newMembers := data.GetChange(membersProp)
+		err = updateDatabaseRoleMembers(ctx, connector, database, roleName, oldMembers.(*schema.Set), newMembers.(*schema.Set))
+	}
+	if err != nil {
 		// If update fails, revert all changed values in the state
 		for prop, oldValue := range oldValues {
 			if err := data.Set(prop, oldValue); err != nil {
@@ -205,24 +253,54 @@ func resourceDatabaseRoleUpdate(ctx context.Context, data *schema.ResourceData,
 		return diag.FromErr(errors.Wrapf(err, "unable to update role [%s].[%s]", database, roleName))
 	}
 
-	data.SetId(getDatabaseRoleID(data))
+	id, err := getDatabaseRoleID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(id)
 
 	logger.Info().Msgf("updated role [%s].[%s]", database, roleName)
 
 	return resourceDatabaseRoleRead(ctx, data, meta)
 }
 
+// updateDatabaseRoleMembers drops the members of oldMembers that are not in
+// newMembers and adds those of newMembers that are not in oldMembers, so
+// members added by other resources are left alone.
+func updateDatabaseRoleMembers(ctx context.Context, connector DatabaseRoleConnector, database, roleName string, oldMembers, newMembers *schema.Set) error {
+	wanted := make(map[string]bool, newMembers.Len())
+	for _, member := range newMembers.List() {
+		wanted[strings.ToLower(member.(string))] = true
+	}
+	current := make(map[string]bool, oldMembers.Len())
+	for _, member := range oldMembers.List() {
+		current[strings.ToLower(member.(string))] = true
+		if wanted[strings.ToLower(member.(string))] {
+			continue
+		}
+		if err := connector.DropDatabaseRoleMember(ctx, database, roleName, member.(string)); err != nil {
+			return err
+		}
+	}
+	for _, member := range newMembers.List() {
+		if current[strings.ToLower(member.(string))] {
+			continue
+		}
+		if err := connector.AddDatabaseRoleMember(ctx, database, roleName, member.(string)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func resourceDatabaseRoleImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	logger := loggerFromMeta(meta, "role", "import")
+	logger := loggerFromMeta(ctx, meta, "role", "import")
 	logger.Debug().Msgf("Import %s", data.Id())
 
-	server, u, err := serverFromId(data.Id())
+	u, err := setServerFromId(meta, data)
 	if err != nil {
 		return nil, err
 	}
-	if err := data.Set(serverProp, server); err != nil {
-		return nil, err
-	}
 
 	parts := strings.Split(u.Path, "/")
 	if len(parts) != 4 {
@@ -235,7 +313,11 @@ func resourceDatabaseRoleImport(ctx context.Context, data *schema.ResourceData,
 		return nil, err
 	}
 
-	data.SetId(getDatabaseRoleID(data))
+	id, err := getDatabaseRoleID(meta, data)
+	if err != nil {
+		return nil, err
+	}
+	data.SetId(id)
 
 	database := data.Get(databaseProp).(string)
 	role_name := data.Get(roleNameProp).(string)
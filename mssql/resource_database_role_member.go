@@ -0,0 +1,212 @@
+package mssql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceDatabaseRoleMember() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDatabaseRoleMemberCreate,
+		ReadContext:   resourceDatabaseRoleMemberRead,
+		DeleteContext: resourceDatabaseRoleMemberDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDatabaseRoleMemberImport,
+		},
+		Schema: map[string]*schema.Schema{
+			serverProp: {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: getServerSchema(serverProp),
+				},
+			},
+			serverRefProp: getServerRefSchema(),
+			databaseProp: {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  defaultDatabaseDefault,
+			},
+			roleNameProp: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.SQLIdentifier,
+			},
+			memberNameProp: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.SQLIdentifier,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: defaultTimeout,
+			Read:   defaultTimeout,
+			Delete: defaultTimeout,
+		},
+	}
+}
+
+type DatabaseRoleMemberConnector interface {
+	AddDatabaseRoleMember(ctx context.Context, database, roleName, memberName string) error
+	GetDatabaseRoleMember(ctx context.Context, database, roleName, memberName string) (*model.DatabaseRoleMember, error)
+	DropDatabaseRoleMember(ctx context.Context, database, roleName, memberName string) error
+	DatabaseExists(ctx context.Context, database string) (bool, error)
+}
+
+func resourceDatabaseRoleMemberCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(ctx, meta, "role_member", "create")
+	id, err := getDatabaseRoleMemberID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debug().Msgf("Create %s", id)
+
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+	memberName := data.Get(memberNameProp).(string)
+
+	connector, err := getDatabaseRoleMemberConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = connector.AddDatabaseRoleMember(ctx, database, roleName, memberName); err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to add [%s] to role [%s].[%s]", memberName, database, roleName))
+	}
+
+	data.SetId(id)
+
+	logger.Info().Msgf("added [%s] to role [%s].[%s]", memberName, database, roleName)
+
+	return resourceDatabaseRoleMemberRead(ctx, data, meta)
+}
+
+func resourceDatabaseRoleMemberRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(ctx, meta, "role_member", "read")
+	logger.Debug().Msgf("Read %s", data.Id())
+
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+	memberName := data.Get(memberNameProp).(string)
+
+	connector, err := getDatabaseRoleMemberConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Check if database exists
+	exists, err := connector.DatabaseExists(ctx, database)
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to check if database [%s] exists", database))
+	}
+	if !exists {
+		logger.Info().Msgf("Database [%s] does not exist", database)
+		data.SetId("")
+		return nil
+	}
+
+	member, err := connector.GetDatabaseRoleMember(ctx, database, roleName, memberName)
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to read member [%s] of role [%s].[%s]", memberName, database, roleName))
+	}
+	if member == nil {
+		logger.Info().Msgf("[%s] is not a member of role [%s].[%s]", memberName, database, roleName)
+		data.SetId("")
+	}
+
+	return nil
+}
+
+func resourceDatabaseRoleMemberDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(ctx, meta, "role_member", "delete")
+	logger.Debug().Msgf("Delete %s", data.Id())
+
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+	memberName := data.Get(memberNameProp).(string)
+
+	connector, err := getDatabaseRoleMemberConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = connector.DropDatabaseRoleMember(ctx, database, roleName, memberName); err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to drop [%s] from role [%s].[%s]", memberName, database, roleName))
+	}
+
+	data.SetId("")
+
+	logger.Info().Msgf("dropped [%s] from role [%s].[%s]", memberName, database, roleName)
+
+	return nil
+}
+
+func resourceDatabaseRoleMemberImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	logger := loggerFromMeta(ctx, meta, "role_member", "import")
+	logger.Debug().Msgf("Import %s", data.Id())
+
+	u, err := setServerFromId(meta, data)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(u.Path, "/")
+	if len(parts) != 6 || parts[2] != "role" || parts[4] != "member" {
+		return nil, errors.New("invalid ID")
+	}
+	if err = data.Set(databaseProp, parts[1]); err != nil {
+		return nil, err
+	}
+	if err = data.Set(roleNameProp, parts[3]); err != nil {
+		return nil, err
+	}
+	if err = data.Set(memberNameProp, parts[5]); err != nil {
+		return nil, err
+	}
+
+	id, err := getDatabaseRoleMemberID(meta, data)
+	if err != nil {
+		return nil, err
+	}
+	data.SetId(id)
+
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+	memberName := data.Get(memberNameProp).(string)
+
+	connector, err := getDatabaseRoleMemberConnector(meta, data)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := connector.GetDatabaseRoleMember(ctx, database, roleName, memberName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read member [%s] of role [%s].[%s] for import", memberName, database, roleName)
+	}
+
+	if member == nil {
+		return nil, errors.Errorf("[%s] is not a member of role [%s].[%s]", memberName, database, roleName)
+	}
+
+	return []*schema.ResourceData{data}, nil
+}
+
+func getDatabaseRoleMemberConnector(meta interface{}, data *schema.ResourceData) (DatabaseRoleMemberConnector, error) {
+	provider := meta.(model.Provider)
+	connector, err := provider.GetConnector(serverProp, data)
+	if err != nil {
+		return nil, err
+	}
+	return connector.(DatabaseRoleMemberConnector), nil
+}
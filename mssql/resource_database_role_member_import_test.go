@@ -0,0 +1,31 @@
+package mssql
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDatabaseRoleMember_Local_BasicImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckDatabaseRoleMemberDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatabaseRoleMember(t, "test_import", "login", map[string]interface{}{"role_name": "test_db_member_role_import", "username": "test_db_member_user_import", "login_name": "test_db_member_login_import", "login_password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatabaseRoleMemberExists("mssql_database_role_member.test_import"),
+				),
+			},
+			{
+				ResourceName:      "mssql_database_role_member.test_import",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccImportStateId("mssql_database_role_member.test_import", false),
+			},
+		},
+	})
+}
@@ -0,0 +1,196 @@
+package mssql
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDatabaseRoleMember_Local_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckDatabaseRoleMemberDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatabaseRoleMember(t, "local_test", "login", map[string]interface{}{"role_name": "test_db_member_role", "username": "test_db_member_user", "login_name": "test_db_member_login", "login_password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatabaseRoleMemberExists("mssql_database_role_member.local_test"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.local_test", "id", "sqlserver://localhost:1433/master/role/test_db_member_role/member/test_db_member_user"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.local_test", "database", "master"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.local_test", "role_name", "test_db_member_role"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.local_test", "member_name", "test_db_member_user"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDatabaseRoleMember_Local_NestedRole(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckDatabaseRoleMemberDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatabaseRoleMember(t, "local_test_nested", "login", map[string]interface{}{"role_name": "test_db_member_parent", "member_role_name": "test_db_member_child"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatabaseRoleMemberExists("mssql_database_role_member.local_test_nested"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.local_test_nested", "role_name", "test_db_member_parent"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.local_test_nested", "member_name", "test_db_member_child"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDatabaseRoleMember_Local_UserWithoutRoles(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckDatabaseRoleMemberDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatabaseRoleMember(t, "local_test_user", "login", map[string]interface{}{"role_name": "test_db_member_role_user", "username": "test_db_member_user_roles", "login_name": "test_db_member_login_roles", "login_password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatabaseRoleMemberExists("mssql_database_role_member.local_test_user"),
+				),
+			},
+			{
+				Config: testAccCheckDatabaseRoleMember(t, "local_test_user", "login", map[string]interface{}{"role_name": "test_db_member_role_user", "username": "test_db_member_user_roles", "login_name": "test_db_member_login_roles", "login_password": "valueIsH8kd$¡", "default_schema": "sys"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatabaseRoleMemberExists("mssql_database_role_member.local_test_user"),
+					testAccCheckUserExists("mssql_user.local_test_user", Check{"default_schema", "==", "sys"}),
+					resource.TestCheckResourceAttr("mssql_user.local_test_user", "roles.#", "1"),
+					resource.TestCheckTypeSetElemAttr("mssql_user.local_test_user", "roles.*", "test_db_member_role_user"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDatabaseRoleMember(t *testing.T, name string, login string, data map[string]interface{}) string {
+	text := `
+			{{ if .login_name }}
+				resource "mssql_login" "{{ .name }}" {
+					server {
+						host = "{{ .host }}"
+						{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+					}
+					login_name = "{{ .login_name }}"
+					password   = "{{ .login_password }}"
+				}
+				resource "mssql_user" "{{ .name }}" {
+					server {
+						host = "{{ .host }}"
+						{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+					}
+					username   = "{{ .username }}"
+					login_name = mssql_login.{{ .name }}.login_name
+					{{ with .default_schema }}default_schema = "{{ . }}"{{ end }}
+				}
+			{{ else }}
+				resource "mssql_database_role" "{{ .name }}_member" {
+					server {
+						host = "{{ .host }}"
+						{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+					}
+					role_name = "{{ .member_role_name }}"
+				}
+			{{ end }}
+			resource "mssql_database_role" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				role_name = "{{ .role_name }}"
+			}
+			resource "mssql_database_role_member" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				role_name   = mssql_database_role.{{ .name }}.role_name
+				{{ if .login_name }}
+				member_name = mssql_user.{{ .name }}.username
+				{{ else }}
+				member_name = mssql_database_role.{{ .name }}_member.role_name
+				{{ end }}
+			}`
+
+	data["name"] = name
+	data["login"] = login
+	if login == "fedauth" || login == "msi" || login == "azure" {
+		data["host"] = os.Getenv("TF_ACC_SQL_SERVER")
+	} else if login == "login" {
+		data["host"] = "localhost"
+	} else {
+		t.Fatalf("login expected to be one of 'login', 'azure', 'msi', 'fedauth', got %s", login)
+	}
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return res
+}
+
+func testAccCheckDatabaseRoleMemberDestroy(state *terraform.State) error {
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "mssql_database_role_member" {
+			continue
+		}
+
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+
+		database := rs.Primary.Attributes["database"]
+		roleName := rs.Primary.Attributes["role_name"]
+		memberName := rs.Primary.Attributes["member_name"]
+		member, err := connector.GetDatabaseRoleMember(database, roleName, memberName)
+		if member != nil {
+			return fmt.Errorf("database role member still exists")
+		}
+		if err != nil {
+			return fmt.Errorf("expected no error, got %s", err)
+		}
+	}
+	return nil
+}
+
+func testAccCheckDatabaseRoleMemberExists(resource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("not found: %s", resource)
+		}
+		if rs.Type != "mssql_database_role_member" {
+			return fmt.Errorf("expected resource of type %s, got %s", "mssql_database_role_member", rs.Type)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no record ID is set")
+		}
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+		database := rs.Primary.Attributes["database"]
+		roleName := rs.Primary.Attributes["role_name"]
+		memberName := rs.Primary.Attributes["member_name"]
+		member, err := connector.GetDatabaseRoleMember(database, roleName, memberName)
+		if err != nil {
+			return fmt.Errorf("error: %s", err)
+		}
+		if member == nil {
+			return fmt.Errorf("%s is not a member of role %s", memberName, roleName)
+		}
+		return nil
+	}
+}
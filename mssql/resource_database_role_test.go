@@ -1,10 +1,13 @@
 package mssql
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -150,6 +153,32 @@ func TestAccDatabaseRole_Local_Basic_Update(t *testing.T) {
 	})
 }
 
+func TestAccDatabaseRole_Local_Update_members(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckRoleDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckRole(t, "local_test_members", "login", map[string]interface{}{"role_name": "test_role_members", "username": "test_role_members_user", "login_name": "test_role_members_login", "login_password": "valueIsH8kd$¡", "members": "[\"test_role_members_user\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists("mssql_database_role.local_test_members", Check{"members", "==", "test_role_members_user"}),
+					resource.TestCheckResourceAttr("mssql_database_role.local_test_members", "members.#", "1"),
+					resource.TestCheckTypeSetElemAttr("mssql_database_role.local_test_members", "members.*", "test_role_members_user"),
+				),
+			},
+			{
+				Config: testAccCheckRole(t, "local_test_members", "login", map[string]interface{}{"role_name": "test_role_members", "username": "test_role_members_user", "login_name": "test_role_members_login", "login_password": "valueIsH8kd$¡", "members": "[]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists("mssql_database_role.local_test_members", Check{"members", "==", ""}),
+					resource.TestCheckResourceAttr("mssql_database_role.local_test_members", "members.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccDatabaseRole_Local_Basic_Update_owner(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
@@ -336,6 +365,7 @@ func testAccCheckRole(t *testing.T, name string, login string, data map[string]i
 				{{ with .database }}database = "{{ . }}"{{ end }}
 				role_name = "{{ .role_name }}"
 				{{ with .owner_name }}owner_name = "{{ . }}"{{ end }}
+				{{ with .members }}members = {{ . }}{{ end }}
 				{{ if .username }}
 				depends_on = [mssql_user.{{ .name }}]
 				{{ end }}
@@ -414,6 +444,8 @@ func testAccCheckRoleExists(resource string, checks ...Check) resource.TestCheck
 				actual = role.RoleName
 			case "owner_name":
 				actual = role.OwnerName
+			case "members":
+				actual = strings.Join(role.Members, ",")
 			default:
 				return fmt.Errorf("unknown property %s", check.name)
 			}
@@ -427,3 +459,78 @@ func testAccCheckRoleExists(resource string, checks ...Check) resource.TestCheck
 		return nil
 	}
 }
+
+// fakeDatabaseRoleConnector records the member changes made to role.
+type fakeDatabaseRoleConnector struct {
+	role  model.DatabaseRole
+	calls []string
+}
+
+func (c *fakeDatabaseRoleConnector) CreateDatabaseRole(context.Context, string, string, string) error {
+	return nil
+}
+
+func (c *fakeDatabaseRoleConnector) GetDatabaseRole(context.Context, string, string) (*model.DatabaseRole, error) {
+	role := c.role
+	return &role, nil
+}
+
+func (c *fakeDatabaseRoleConnector) UpdateDatabaseRole(context.Context, string, int, string, string) error {
+	return nil
+}
+
+func (c *fakeDatabaseRoleConnector) DeleteDatabaseRole(context.Context, string, string) error {
+	return nil
+}
+
+func (c *fakeDatabaseRoleConnector) UpdateDatabaseRoleMembers(_ context.Context, _, _ string, members []string) error {
+	c.calls = append(c.calls, fmt.Sprintf("set %v", members))
+	return nil
+}
+
+func (c *fakeDatabaseRoleConnector) AddDatabaseRoleMember(_ context.Context, _, _, memberName string) error {
+	c.calls = append(c.calls, "add "+memberName)
+	return nil
+}
+
+func (c *fakeDatabaseRoleConnector) DropDatabaseRoleMember(_ context.Context, _, _, memberName string) error {
+	c.calls = append(c.calls, "drop "+memberName)
+	return nil
+}
+
+func (c *fakeDatabaseRoleConnector) DatabaseExists(context.Context, string) (bool, error) {
+	return true, nil
+}
+
+func TestDatabaseRoleUpdate_Members(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		members  interface{}
+		expected []string
+	}{
+		{"shrink", []interface{}{"a"}, []string{"drop b"}},
+		{"grow", []interface{}{"a", "b", "c"}, []string{"add c"}},
+		{"replace", []interface{}{"A", "c"}, []string{"drop b", "add c"}},
+		{"empty", []interface{}{}, []string{"drop a", "drop b"}},
+		{"omit", nil, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			connector := &fakeDatabaseRoleConnector{role: model.DatabaseRole{RoleName: "role", Members: []string{"a", "b", "other"}}}
+			meta := fakeProvider{connector: connector}
+			config := map[string]interface{}{"database": "db", "role_name": "role"}
+			if tc.members != nil {
+				config["members"] = tc.members
+			}
+			data := updateData(t, resourceDatabaseRole(),
+				map[string]interface{}{"database": "db", "role_name": "role", "members": []interface{}{"a", "b"}},
+				config, meta)
+
+			if diags := resourceDatabaseRoleUpdate(context.Background(), data, meta); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if fmt.Sprint(connector.calls) != fmt.Sprint(tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, connector.calls)
+			}
+		})
+	}
+}
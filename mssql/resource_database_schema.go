@@ -24,11 +24,12 @@ func resourceDatabaseSchema() *schema.Resource {
 			serverProp: {
 				Type:     schema.TypeList,
 				MaxItems: 1,
-				Required: true,
+				Optional: true,
 				Elem: &schema.Resource{
 					Schema: getServerSchema(serverProp),
 				},
 			},
+			serverRefProp: getServerRefSchema(),
 			ignoreDeletionProp: {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -81,8 +82,12 @@ type DatabaseSchemaConnector interface {
 }
 
 func resourceDatabaseSchemaCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "schema", "create")
-	logger.Debug().Msgf("Create %s", getDatabaseSchemaID(data))
+	logger := loggerFromMeta(ctx, meta, "schema", "create")
+	id, err := getDatabaseSchemaID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debug().Msgf("Create %s", id)
 
 	database := data.Get(databaseProp).(string)
 	schemaName := data.Get(schemaNameProp).(string)
@@ -97,7 +102,7 @@ func resourceDatabaseSchemaCreate(ctx context.Context, data *schema.ResourceData
 		return diag.FromErr(errors.Wrapf(err, "unable to create schema [%s].[%s]", database, schemaName))
 	}
 
-	data.SetId(getDatabaseSchemaID(data))
+	data.SetId(id)
 
 	logger.Info().Msgf("created schema [%s].[%s]", database, schemaName)
 
@@ -105,7 +110,7 @@ func resourceDatabaseSchemaCreate(ctx context.Context, data *schema.ResourceData
 }
 
 func resourceDatabaseSchemaRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "schema", "read")
+	logger := loggerFromMeta(ctx, meta, "schema", "read")
 	logger.Debug().Msgf("Read %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -162,7 +167,7 @@ func resourceDatabaseSchemaDelete(ctx context.Context, data *schema.ResourceData
     return nil
   }
 
-	logger := loggerFromMeta(meta, "schema", "delete")
+	logger := loggerFromMeta(ctx, meta, "schema", "delete")
 	logger.Debug().Msgf("Delete %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -185,7 +190,7 @@ func resourceDatabaseSchemaDelete(ctx context.Context, data *schema.ResourceData
 }
 
 func resourceDatabaseSchemaUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "schema", "update")
+	logger := loggerFromMeta(ctx, meta, "schema", "update")
 	logger.Debug().Msgf("Update %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -216,7 +221,11 @@ func resourceDatabaseSchemaUpdate(ctx context.Context, data *schema.ResourceData
 		return diag.FromErr(errors.Wrapf(err, "unable to update schema [%s].[%s]", database, schemaName))
 	}
 
-	data.SetId(getDatabaseSchemaID(data))
+	id, err := getDatabaseSchemaID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(id)
 
 	logger.Info().Msgf("updated schema [%s].[%s]", database, schemaName)
 
@@ -224,16 +233,13 @@ func resourceDatabaseSchemaUpdate(ctx context.Context, data *schema.ResourceData
 }
 
 func resourceDatabaseSchemaImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	logger := loggerFromMeta(meta, "schema", "import")
+	logger := loggerFromMeta(ctx, meta, "schema", "import")
 	logger.Debug().Msgf("Import %s", data.Id())
 
-	server, u, err := serverFromId(data.Id())
+	u, err := setServerFromId(meta, data)
 	if err != nil {
 		return nil, err
 	}
-	if err := data.Set(serverProp, server); err != nil {
-		return nil, err
-	}
 
 	parts := strings.Split(u.Path, "/")
 	if len(parts) != 4 {
@@ -246,7 +252,11 @@ func resourceDatabaseSchemaImport(ctx context.Context, data *schema.ResourceData
 		return nil, err
 	}
 
-	data.SetId(getDatabaseSchemaID(data))
+	id, err := getDatabaseSchemaID(meta, data)
+	if err != nil {
+		return nil, err
+	}
+	data.SetId(id)
 
 	database := data.Get(databaseProp).(string)
 	schemaName := data.Get(schemaNameProp).(string)
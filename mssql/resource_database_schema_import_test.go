@@ -29,3 +29,45 @@ func TestAccDatabaseSchema_Local_BasicImport(t *testing.T) {
 		},
 	})
 }
+
+func TestAccDatabaseSchema_Local_DefaultServerImport(t *testing.T) {
+	config := `
+		provider "mssql" {
+			server {
+				host = "localhost"
+				login {}
+			}
+		}
+		resource "mssql_database_schema" "test_import_default" {
+			schema_name = "test_schema_import_default"
+		}`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckSchemaDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSchemaExists("mssql_database_schema.test_import_default"),
+					resource.TestCheckResourceAttr("mssql_database_schema.test_import_default", "server.#", "0"),
+				),
+			},
+			{
+				// The ID of the provider default server imports without a
+				// server block, so the imported state matches the configuration
+				// and the next plan is empty instead of replacing the schema.
+				Config:            config,
+				ResourceName:      "mssql_database_schema.test_import_default",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
@@ -27,11 +27,12 @@ func resourceDatabaseSQLScript() *schema.Resource {
 			serverProp: {
 				Type:     schema.TypeList,
 				MaxItems: 1,
-				Required: true,
+				Optional: true,
 				Elem: &schema.Resource{
 					Schema: getServerSchema(serverProp),
 				},
 			},
+			serverRefProp: getServerRefSchema(),
 			databaseProp: {
 				Type:     schema.TypeString,
 				Required: true,
@@ -144,6 +145,7 @@ func resourceDatabaseSQLScript() *schema.Resource {
 
 type DatabaseSQLScriptConnector interface {
 	DataBaseExecuteScript(ctx context.Context, database string, sqlscript string) error
+	DataBaseVerifyObject(ctx context.Context, database string, query string) error
 	DatabaseExists(ctx context.Context, database string) (bool, error)
 }
 
@@ -296,8 +298,12 @@ func validateVerifyObject(verifyObject string) error {
 }
 
 func resourceDatabaseSQLScriptCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "sqlscript", "create")
-	logger.Debug().Msgf("Create %s", getDatabaseSQLScriptID(data))
+	logger := loggerFromMeta(ctx, meta, "sqlscript", "create")
+	id, err := getDatabaseSQLScriptID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debug().Msgf("Create %s", id)
 
 	database := data.Get(databaseProp).(string)
 	script, err := getScript(data)
@@ -314,7 +320,7 @@ func resourceDatabaseSQLScriptCreate(ctx context.Context, data *schema.ResourceD
 		return diag.FromErr(errors.Wrapf(err, "unable to execute SQL script in database [%s]", database))
 	}
 
-	data.SetId(getDatabaseSQLScriptID(data))
+	data.SetId(id)
 
 	logger.Info().Msgf("executed SQL script in database [%s]", database)
 
@@ -322,7 +328,7 @@ func resourceDatabaseSQLScriptCreate(ctx context.Context, data *schema.ResourceD
 }
 
 func resourceDatabaseSQLScriptRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "sqlscript", "read")
+	logger := loggerFromMeta(ctx, meta, "sqlscript", "read")
 	logger.Debug().Msgf("Read %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -350,7 +356,7 @@ func resourceDatabaseSQLScriptRead(ctx context.Context, data *schema.ResourceDat
 	}
 
 	// Execute the verification query
-	err = connector.DataBaseExecuteScript(ctx, database, query)
+	err = connector.DataBaseVerifyObject(ctx, database, query)
 	if err != nil {
 		// If we're verifying an object and it doesn't exist, mark the resource as gone
 		if verifyObject != "" && (strings.Contains(err.Error(), "Invalid object name") ||
@@ -368,7 +374,7 @@ func resourceDatabaseSQLScriptRead(ctx context.Context, data *schema.ResourceDat
 }
 
 func resourceDatabaseSQLScriptUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "sqlscript", "update")
+	logger := loggerFromMeta(ctx, meta, "sqlscript", "update")
 	logger.Debug().Msgf("Update %s", data.Id())
 
 	// Only run if script content has changed
@@ -406,7 +412,11 @@ func resourceDatabaseSQLScriptUpdate(ctx context.Context, data *schema.ResourceD
 		return diag.FromErr(errors.Wrapf(err, "unable to execute SQL script in database [%s]", database))
 	}
 
-	data.SetId(getDatabaseSQLScriptID(data))
+	id, err := getDatabaseSQLScriptID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(id)
 
 	logger.Info().Msgf("executed SQL script in database [%s]", database)
 
@@ -414,7 +424,7 @@ func resourceDatabaseSQLScriptUpdate(ctx context.Context, data *schema.ResourceD
 }
 
 func resourceDatabaseSQLScriptDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "sqlscript", "delete")
+	logger := loggerFromMeta(ctx, meta, "sqlscript", "delete")
 	logger.Debug().Msgf("Delete %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -427,16 +437,13 @@ func resourceDatabaseSQLScriptDelete(ctx context.Context, data *schema.ResourceD
 }
 
 func resourceDatabaseSQLScriptImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	logger := loggerFromMeta(meta, "sqlscript", "import")
+	logger := loggerFromMeta(ctx, meta, "sqlscript", "import")
 	logger.Debug().Msgf("Import %s", data.Id())
 
-	server, u, err := serverFromId(data.Id())
+	u, err := setServerFromId(meta, data)
 	if err != nil {
 		return nil, err
 	}
-	if err := data.Set(serverProp, server); err != nil {
-		return nil, err
-	}
 
 	// Split the import ID into parts
 	parts := strings.Split(u.Path, "/")
@@ -476,7 +483,11 @@ func resourceDatabaseSQLScriptImport(ctx context.Context, data *schema.ResourceD
 		return nil, fmt.Errorf("failed to set verify_object: %v", err)
 	}
 
-	data.SetId(getDatabaseSQLScriptID(data))
+	id, err := getDatabaseSQLScriptID(meta, data)
+	if err != nil {
+		return nil, err
+	}
+	data.SetId(id)
 
 	// Get the connector
 	connector, err := getDatabaseSQLScriptConnector(meta, data)
@@ -499,7 +510,7 @@ func resourceDatabaseSQLScriptImport(ctx context.Context, data *schema.ResourceD
 		return nil, fmt.Errorf("failed to generate verification query: %v", err)
 	}
 
-	err = connector.DataBaseExecuteScript(ctx, database, query)
+	err = connector.DataBaseVerifyObject(ctx, database, query)
 	if err != nil {
 		return nil, fmt.Errorf("object '%s' does not exist in database '%s': %v", verifyObject, database, err)
 	}
@@ -132,7 +132,7 @@ func testAccCheckDatabaseSQLScriptExists(resource string) resource.TestCheckFunc
 		if err != nil {
 			return fmt.Errorf("error: %s", err)
 		}
-		err = connector.DataBaseExecuteScript(database, query)
+		err = connector.DataBaseVerifyObject(database, query)
 		if err != nil {
 			return fmt.Errorf("error: %s", err)
 		}
@@ -15,6 +15,7 @@ func resourceEntraIDLogin() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceEntraIDLoginCreate,
 		ReadContext:   resourceEntraIDLoginRead,
+		UpdateContext: resourceEntraIDLoginUpdate,
 		DeleteContext: resourceEntraIDLoginDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceEntraIDLoginImport,
@@ -23,12 +24,13 @@ func resourceEntraIDLogin() *schema.Resource {
 			serverProp: {
 				Type:     schema.TypeList,
 				MaxItems: 1,
-				Required: true,
+				Optional: true,
 				ForceNew: true,
 				Elem: &schema.Resource{
 					Schema: getServerSchema(serverProp),
 				},
 			},
+			serverRefProp: getServerRefSchema(),
 			loginNameProp: {
 				Type:     schema.TypeString,
 				Required: true,
@@ -56,6 +58,17 @@ func resourceEntraIDLogin() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			serverRolesProp: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		CustomizeDiff: func(ctx context.Context, data *schema.ResourceDiff, meta interface{}) error {
+			return customizeDiffEmptySet(data, serverRolesProp)
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: defaultTimeout,
@@ -70,11 +83,16 @@ type EntraIDLoginConnector interface {
 	CreateEntraIDLogin(ctx context.Context, name, objectId string) error
 	GetEntraIDLogin(ctx context.Context, name string) (*model.EntraIDLogin, error)
 	DeleteEntraIDLogin(ctx context.Context, name string) error
+	UpdateServerRoles(ctx context.Context, memberName string, roles []string) error
 }
 
 func resourceEntraIDLoginCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "EntraIDLogin", "create")
-	logger.Debug().Msgf("Create %s", getLoginID(data))
+	logger := loggerFromMeta(ctx, meta, "EntraIDLogin", "create")
+	id, err := getLoginID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debug().Msgf("Create %s", id)
 
 	loginName := data.Get(loginNameProp).(string)
 	objectId := data.Get(objectIdProp).(string)
@@ -88,7 +106,13 @@ func resourceEntraIDLoginCreate(ctx context.Context, data *schema.ResourceData,
 		return diag.FromErr(errors.Wrapf(err, "unable to create EntraID Login [%s]", loginName))
 	}
 
-	data.SetId(getLoginID(data))
+	data.SetId(id)
+
+	if roles, ok := data.GetOk(serverRolesProp); ok {
+		if err = connector.UpdateServerRoles(ctx, loginName, toStringSlice(roles.(*schema.Set).List())); err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to add EntraID Login [%s] to its server roles", loginName))
+		}
+	}
 
 	logger.Info().Msgf("created EntraID Login [%s]", loginName)
 
@@ -96,7 +120,7 @@ func resourceEntraIDLoginCreate(ctx context.Context, data *schema.ResourceData,
 }
 
 func resourceEntraIDLoginRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "EntraIDLogin", "read")
+	logger := loggerFromMeta(ctx, meta, "EntraIDLogin", "read")
 	logger.Debug().Msgf("Read %s", data.Id())
 
 	loginName := data.Get(loginNameProp).(string)
@@ -126,13 +150,41 @@ func resourceEntraIDLoginRead(ctx context.Context, data *schema.ResourceData, me
 		if err = data.Set(principalIdProp, EntraIDLogin.PrincipalID); err != nil {
 			return diag.FromErr(err)
 		}
+		if err = data.Set(serverRolesProp, matchSetCase(data, serverRolesProp, EntraIDLogin.ServerRoles)); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return nil
 }
 
+func resourceEntraIDLoginUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(ctx, meta, "EntraIDLogin", "update")
+	logger.Debug().Msgf("Update %s", data.Id())
+
+	loginName := data.Get(loginNameProp).(string)
+	roles := toStringSlice(data.Get(serverRolesProp).(*schema.Set).List())
+
+	connector, err := getEntraIDLoginConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = connector.UpdateServerRoles(ctx, loginName, roles); err != nil {
+		oldValue, _ := data.GetChange(serverRolesProp)
+		if err := data.Set(serverRolesProp, oldValue); err != nil {
+			logger.Error().Err(err).Msgf("Failed to revert %s state after update error", serverRolesProp)
+		}
+		return diag.FromErr(errors.Wrapf(err, "unable to update the server roles of EntraID Login [%s]", loginName))
+	}
+
+	logger.Info().Msgf("updated EntraID Login [%s]", loginName)
+
+	return resourceEntraIDLoginRead(ctx, data, meta)
+}
+
 func resourceEntraIDLoginDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "EntraIDLogin", "delete")
+	logger := loggerFromMeta(ctx, meta, "EntraIDLogin", "delete")
 	logger.Debug().Msgf("Delete %s", data.Id())
 
 	loginName := data.Get(loginNameProp).(string)
@@ -154,16 +206,13 @@ func resourceEntraIDLoginDelete(ctx context.Context, data *schema.ResourceData,
 }
 
 func resourceEntraIDLoginImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	logger := loggerFromMeta(meta, "EntraIDLogin", "import")
+	logger := loggerFromMeta(ctx, meta, "EntraIDLogin", "import")
 	logger.Debug().Msgf("Import %s", data.Id())
 
-	server, u, err := serverFromId(data.Id())
+	u, err := setServerFromId(meta, data)
 	if err != nil {
 		return nil, err
 	}
-	if err = data.Set(serverProp, server); err != nil {
-		return nil, err
-	}
 
 	parts := strings.Split(u.Path, "/")
 	if len(parts) != 3 {
@@ -173,7 +222,11 @@ func resourceEntraIDLoginImport(ctx context.Context, data *schema.ResourceData,
 		return nil, err
 	}
 
-	data.SetId(getLoginID(data))
+	id, err := getLoginID(meta, data)
+	if err != nil {
+		return nil, err
+	}
+	data.SetId(id)
 
 	loginName := data.Get(loginNameProp).(string)
 
@@ -206,6 +259,9 @@ func resourceEntraIDLoginImport(ctx context.Context, data *schema.ResourceData,
 	if err = data.Set(principalIdProp, EntraIDLogin.PrincipalID); err != nil {
 		return nil, err
 	}
+	if err = data.Set(serverRolesProp, EntraIDLogin.ServerRoles); err != nil {
+		return nil, err
+	}
 
 	return []*schema.ResourceData{data}, nil
 }
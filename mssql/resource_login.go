@@ -24,11 +24,12 @@ func resourceLogin() *schema.Resource {
 			serverProp: {
 				Type:     schema.TypeList,
 				MaxItems: 1,
-				Required: true,
+				Optional: true,
 				Elem: &schema.Resource{
 					Schema: getServerSchema(serverProp),
 				},
 			},
+			serverRefProp: getServerRefSchema(),
 			loginNameProp: {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -66,7 +67,16 @@ func resourceLogin() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			serverRolesProp: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
+		CustomizeDiff: resourceLoginCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Create: defaultTimeout,
 			Read:   defaultTimeout,
@@ -76,16 +86,46 @@ func resourceLogin() *schema.Resource {
 	}
 }
 
+// resourceLoginCustomizeDiff rejects a default database or language on Azure
+// SQL Database, where logins always use master and us_english.
+func resourceLoginCustomizeDiff(ctx context.Context, data *schema.ResourceDiff, meta interface{}) error {
+	if err := customizeDiffEmptySet(data, serverRolesProp); err != nil {
+		return err
+	}
+	defaultDatabase := data.Get(defaultDatabaseProp).(string)
+	defaultLanguage := data.Get(defaultLanguageProp).(string)
+	if defaultDatabase == "" {
+		defaultDatabase = defaultDatabaseDefault
+	}
+	if defaultDatabase == defaultDatabaseDefault && (defaultLanguage == "" || defaultLanguage == "us_english") {
+		return nil
+	}
+
+	capabilities := planServerCapabilities(ctx, meta, data, "login")
+	if capabilities == nil || !capabilities.IsAzureSQLDatabase() {
+		return nil
+	}
+	if defaultDatabase != defaultDatabaseDefault {
+		return errors.Errorf("DEFAULT_DATABASE not supported on Azure SQL Database, remove %s", defaultDatabaseProp)
+	}
+	return errors.Errorf("DEFAULT_LANGUAGE not supported on Azure SQL Database, remove %s", defaultLanguageProp)
+}
+
 type LoginConnector interface {
 	CreateLogin(ctx context.Context, name, password, sid, defaultDatabase, defaultLanguage string) error
 	GetLogin(ctx context.Context, name string) (*model.Login, error)
 	UpdateLogin(ctx context.Context, name, password, defaultDatabase, defaultLanguage string) error
 	DeleteLogin(ctx context.Context, name string) error
+	UpdateServerRoles(ctx context.Context, memberName string, roles []string) error
 }
 
 func resourceLoginCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "login", "create")
-	logger.Debug().Msgf("Create %s", getLoginID(data))
+	logger := loggerFromMeta(ctx, meta, "login", "create")
+	id, err := getLoginID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debug().Msgf("Create %s", id)
 
 	loginName := data.Get(loginNameProp).(string)
 	password := data.Get(passwordProp).(string)
@@ -102,7 +142,13 @@ func resourceLoginCreate(ctx context.Context, data *schema.ResourceData, meta in
 		return diag.FromErr(errors.Wrapf(err, "unable to create login [%s]", loginName))
 	}
 
-	data.SetId(getLoginID(data))
+	data.SetId(id)
+
+	if roles, ok := data.GetOk(serverRolesProp); ok {
+		if err = connector.UpdateServerRoles(ctx, loginName, toStringSlice(roles.(*schema.Set).List())); err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to add login [%s] to its server roles", loginName))
+		}
+	}
 
 	logger.Info().Msgf("created login [%s]", loginName)
 
@@ -110,7 +156,7 @@ func resourceLoginCreate(ctx context.Context, data *schema.ResourceData, meta in
 }
 
 func resourceLoginRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "login", "read")
+	logger := loggerFromMeta(ctx, meta, "login", "read")
 	logger.Debug().Msgf("Read %s", data.Id())
 
 	loginName := data.Get(loginNameProp).(string)
@@ -140,13 +186,16 @@ func resourceLoginRead(ctx context.Context, data *schema.ResourceData, meta inte
 		if err = data.Set(defaultLanguageProp, login.DefaultLanguage); err != nil {
 			return diag.FromErr(err)
 		}
+		if err = data.Set(serverRolesProp, matchSetCase(data, serverRolesProp, login.ServerRoles)); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return nil
 }
 
 func resourceLoginUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "login", "update")
+	logger := loggerFromMeta(ctx, meta, "login", "update")
 	logger.Debug().Msgf("Update %s", data.Id())
 
 	loginName := data.Get(loginNameProp).(string)
@@ -168,17 +217,34 @@ func resourceLoginUpdate(ctx context.Context, data *schema.ResourceData, meta in
 		return diag.FromErr(err)
 	}
 
-	if err = connector.UpdateLogin(ctx, loginName, password, defaultDatabase, defaultLanguage); err != nil {
-		// If update fails, revert all changed values in the state
-		for prop, oldValue := range oldValues {
-			if err := data.Set(prop, oldValue); err != nil {
-				logger.Error().Err(err).Msgf("Failed to revert %s state after update error", prop)
+	if data.HasChanges(passwordProp, defaultDatabaseProp, defaultLanguageProp) {
+		if err = connector.UpdateLogin(ctx, loginName, password, defaultDatabase, defaultLanguage); err != nil {
+			// If update fails, revert all changed values in the state
+			for prop, oldValue := range oldValues {
+				if err := data.Set(prop, oldValue); err != nil {
+					logger.Error().Err(err).Msgf("Failed to revert %s state after update error", prop)
+				}
 			}
+			return diag.FromErr(errors.Wrapf(err, "unable to update login [%s]", loginName))
 		}
-		return diag.FromErr(errors.Wrapf(err, "unable to update login [%s]", loginName))
 	}
 
-	data.SetId(getLoginID(data))
+	if data.HasChange(serverRolesProp) {
+		roles := toStringSlice(data.Get(serverRolesProp).(*schema.Set).List())
+		if err = connector.UpdateServerRoles(ctx, loginName, roles); err != nil {
+			oldValue, _ := data.GetChange(serverRolesProp)
+			if err := data.Set(serverRolesProp, oldValue); err != nil {
+				logger.Error().Err(err).Msgf("Failed to revert %s state after update error", serverRolesProp)
+			}
+			return diag.FromErr(errors.Wrapf(err, "unable to update the server roles of login [%s]", loginName))
+		}
+	}
+
+	id, err := getLoginID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(id)
 
 	logger.Info().Msgf("updated login [%s]", loginName)
 
@@ -186,7 +252,7 @@ func resourceLoginUpdate(ctx context.Context, data *schema.ResourceData, meta in
 }
 
 func resourceLoginDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "login", "delete")
+	logger := loggerFromMeta(ctx, meta, "login", "delete")
 	logger.Debug().Msgf("Delete %s", data.Id())
 
 	loginName := data.Get(loginNameProp).(string)
@@ -209,16 +275,13 @@ func resourceLoginDelete(ctx context.Context, data *schema.ResourceData, meta in
 }
 
 func resourceLoginImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	logger := loggerFromMeta(meta, "login", "import")
+	logger := loggerFromMeta(ctx, meta, "login", "import")
 	logger.Debug().Msgf("Import %s", data.Id())
 
-	server, u, err := serverFromId(data.Id())
+	u, err := setServerFromId(meta, data)
 	if err != nil {
 		return nil, err
 	}
-	if err = data.Set(serverProp, server); err != nil {
-		return nil, err
-	}
 
 	parts := strings.Split(u.Path, "/")
 	if len(parts) != 3 {
@@ -228,7 +291,11 @@ func resourceLoginImport(ctx context.Context, data *schema.ResourceData, meta in
 		return nil, err
 	}
 
-	data.SetId(getLoginID(data))
+	id, err := getLoginID(meta, data)
+	if err != nil {
+		return nil, err
+	}
+	data.SetId(id)
 
 	loginName := data.Get(loginNameProp).(string)
 
@@ -258,6 +325,9 @@ func resourceLoginImport(ctx context.Context, data *schema.ResourceData, meta in
 	if err = data.Set(defaultLanguageProp, login.DefaultLanguage); err != nil {
 		return nil, err
 	}
+	if err = data.Set(serverRolesProp, login.ServerRoles); err != nil {
+		return nil, err
+	}
 
 	return []*schema.ResourceData{data}, nil
 }
@@ -30,3 +30,27 @@ func TestAccLogin_Local_BasicImport(t *testing.T) {
 		},
 	})
 }
+
+func TestAccLogin_Local_AuthImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckLoginDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckLogin(t, "test_import", "login", map[string]interface{}{"login_name": "login_import", "password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLoginExists("mssql_login.test_import"),
+				),
+			},
+			{
+				ResourceName:            "mssql_login.test_import",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+				ImportStateIdFunc:       testAccImportStateIdQuery("mssql_login.test_import", "auth=login"),
+			},
+		},
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -40,6 +41,83 @@ func TestAccLogin_Local_Basic(t *testing.T) {
 	})
 }
 
+func TestAccLogin_Local_ServerProfile(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckLoginDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckLogin(t, "profile", "profile", map[string]interface{}{"login_name": "login_profile", "password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLoginExists("mssql_login.profile"),
+					resource.TestCheckResourceAttr("mssql_login.profile", "login_name", "login_profile"),
+					resource.TestCheckResourceAttr("mssql_login.profile", "server_ref", "local"),
+					resource.TestCheckResourceAttr("mssql_login.profile", "server.#", "0"),
+					resource.TestCheckResourceAttr("mssql_login.profile", "id", "sqlserver://localhost:1433/login/login_profile"),
+					resource.TestCheckResourceAttrSet("mssql_login.profile", "principal_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLogin_Local_Encrypt_TrustServerCertificate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckLoginDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckLogin(t, "encrypt", "login", map[string]interface{}{"login_name": "login_encrypt", "password": "valueIsH8kd$¡", "encrypt": "true", "trust_server_certificate": "true"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLoginExists("mssql_login.encrypt"),
+					resource.TestCheckResourceAttr("mssql_login.encrypt", "server.0.encrypt", "true"),
+					resource.TestCheckResourceAttr("mssql_login.encrypt", "server.0.trust_server_certificate", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLogin_Local_Encrypt_UntrustedCertificate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckLoginDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				// The local container presents a self-signed certificate, which must be rejected unless trusted
+				Config:      testAccCheckLogin(t, "encrypt_untrusted", "login", map[string]interface{}{"login_name": "login_encrypt_untrusted", "password": "valueIsH8kd$¡", "encrypt": "strict"}),
+				ExpectError: regexp.MustCompile("certificate"),
+			},
+		},
+	})
+}
+
+func TestAccLogin_Local_ConnectionOptions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckLoginDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckLogin(t, "options", "login", map[string]interface{}{"login_name": "login_options", "password": "valueIsH8kd$¡", "app_name": "terraform-acc-test", "packet_size": 8192, "connect_timeout": "45s"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLoginExists("mssql_login.options"),
+					resource.TestCheckResourceAttr("mssql_login.options", "server.0.app_name", "terraform-acc-test"),
+					resource.TestCheckResourceAttr("mssql_login.options", "server.0.packet_size", "8192"),
+					resource.TestCheckResourceAttr("mssql_login.options", "server.0.connect_timeout", "45s"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccLogin_Local_Basic_Pass_Validate_Length(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
@@ -115,6 +193,20 @@ func TestAccLogin_Azure_Basic(t *testing.T) {
 	})
 }
 
+func TestAccLogin_Azure_DefaultLanguage(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckLogin(t, "default_language", "azure", map[string]interface{}{"login_name": "login_default_language", "password": "valueIsH8kd$¡", "default_language": "russian"}),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("DEFAULT_LANGUAGE not supported on Azure SQL Database"),
+			},
+		},
+	})
+}
+
 func TestAccLogin_Azure_Basic_SID(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
@@ -249,6 +341,43 @@ func TestAccLogin_Local_UpdateDefaultLanguage(t *testing.T) {
 		}})
 }
 
+func TestAccLogin_Local_UpdateServerRoles(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckLoginDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckLogin(t, "test_update", "login", map[string]interface{}{"login_name": "login_update", "password": "valueIsH8kd$¡", "server_roles": "[\"dbcreator\", \"securityadmin\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_login.test_update", "server_roles.#", "2"),
+					testAccCheckLoginExists("mssql_login.test_update", Check{"server_roles", "==", "dbcreator,securityadmin"}),
+				),
+			},
+			{
+				Config: testAccCheckLogin(t, "test_update", "login", map[string]interface{}{"login_name": "login_update", "password": "valueIsH8kd$¡", "server_roles": "[\"processadmin\", \"securityadmin\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_login.test_update", "server_roles.#", "2"),
+					testAccCheckLoginExists("mssql_login.test_update", Check{"server_roles", "==", "processadmin,securityadmin"}),
+				),
+			},
+			{
+				Config: testAccCheckLogin(t, "test_update", "login", map[string]interface{}{"login_name": "login_update", "password": "valueIsH8kd$¡", "server_roles": "[\"ProcessAdmin\", \"securityadmin\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("mssql_login.test_update", "server_roles.*", "ProcessAdmin"),
+					testAccCheckLoginExists("mssql_login.test_update", Check{"server_roles", "==", "processadmin,securityadmin"}),
+				),
+			},
+			{
+				Config: testAccCheckLogin(t, "test_update", "login", map[string]interface{}{"login_name": "login_update", "password": "valueIsH8kd$¡", "server_roles": "[]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLoginExists("mssql_login.test_update", Check{"server_roles", "==", ""}),
+				),
+			},
+		}})
+}
+
 func TestAccLogin_Azure_UpdateLoginName(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
@@ -297,26 +426,46 @@ func TestAccLogin_Azure_UpdatePassword(t *testing.T) {
 
 func testAccCheckLogin(t *testing.T, name string, login string, data map[string]interface{}) string {
 	text := `
+			{{ if eq .login "profile" }}
+				provider "mssql" {
+					server_profile {
+						name = "local"
+						host = "{{ .host }}"
+						login {}
+					}
+				}
+			{{ end }}
 			resource "mssql_login" "{{ .name }}" {
+				{{ if eq .login "profile" }}
+				server_ref = "local"
+				{{ else }}
 				server {
 					host = "{{ .host }}"
+					{{ with .encrypt }}encrypt = "{{ . }}"{{ end }}
+					{{ with .trust_server_certificate }}trust_server_certificate = {{ . }}{{ end }}
+					{{ with .host_name_in_certificate }}host_name_in_certificate = "{{ . }}"{{ end }}
+					{{ with .app_name }}app_name = "{{ . }}"{{ end }}
+					{{ with .packet_size }}packet_size = {{ . }}{{ end }}
+					{{ with .connect_timeout }}connect_timeout = "{{ . }}"{{ end }}
 					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
 				}
+				{{ end }}
 				login_name = "{{ .login_name }}"
 				password   = "{{ .password }}"
 				{{ with .sid }}sid = "{{ . }}"{{ end }}
 				{{ with .default_database }}default_database = "{{ . }}"{{ end }}
 				{{ with .default_language }}default_language = "{{ . }}"{{ end }}
+				{{ with .server_roles }}server_roles = {{ . }}{{ end }}
 			}`
 
 	data["name"] = name
 	data["login"] = login
 	if login == "fedauth" || login == "msi" || login == "azure" {
 		data["host"] = os.Getenv("TF_ACC_SQL_SERVER")
-	} else if login == "login" {
+	} else if login == "login" || login == "profile" {
 		data["host"] = "localhost"
 	} else {
-		t.Fatalf("login expected to be one of 'login', 'azure', 'msi', 'fedauth', got %s", login)
+		t.Fatalf("login expected to be one of 'login', 'profile', 'azure', 'msi', 'fedauth', got %s", login)
 	}
 	res, err := templateToString(name, text, data)
 	if err != nil {
@@ -381,6 +530,8 @@ func testAccCheckLoginExists(resource string, checks ...Check) resource.TestChec
 				actual = login.DefaultDatabase
 			case "default_language":
 				actual = login.DefaultLanguage
+			case "server_roles":
+				actual = strings.Join(login.ServerRoles, ",")
 			default:
 				return fmt.Errorf("unknown property %s", check.name)
 			}
@@ -0,0 +1,286 @@
+package mssql
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceServerPermissions() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceServerPermissionsCreate,
+		ReadContext:   resourceServerPermissionsRead,
+		UpdateContext: resourceServerPermissionsUpdate,
+		DeleteContext: resourceServerPermissionsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceServerPermissionsImport,
+		},
+		Schema: map[string]*schema.Schema{
+			serverProp: {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: getServerSchema(serverProp),
+				},
+			},
+			serverRefProp: getServerRefSchema(),
+			principalNameProp: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.SQLIdentifier,
+			},
+			principalIdProp: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			permissionsProp: {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				AtLeastOneOf: []string{permissionsProp, deniedPermissionsProp},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			deniedPermissionsProp: {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				AtLeastOneOf: []string{permissionsProp, deniedPermissionsProp},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: defaultTimeout,
+			Read:   defaultTimeout,
+			Update: defaultTimeout,
+			Delete: defaultTimeout,
+		},
+	}
+}
+
+type ServerPermissionsConnector interface {
+	CreateServerPermissions(ctx context.Context, permissions *model.ServerPermissions) error
+	GetServerPermissions(ctx context.Context, principalName string) (*model.ServerPermissions, error)
+	UpdateServerPermissions(ctx context.Context, permissions *model.ServerPermissions) error
+	DeleteServerPermissions(ctx context.Context, permissions *model.ServerPermissions) error
+}
+
+func resourceServerPermissionsCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(ctx, meta, "serverpermissions", "create")
+	id, err := getServerPermissionsID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debug().Msgf("Create %s", id)
+
+	principalName := data.Get(principalNameProp).(string)
+	permissions := data.Get(permissionsProp).(*schema.Set).List()
+	deniedPermissions := data.Get(deniedPermissionsProp).(*schema.Set).List()
+	permissions_, _ := json.Marshal(permissions)
+
+	connector, err := getServerPermissionsConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serverPermissionsModel := &model.ServerPermissions{
+		PrincipalName:     principalName,
+		Permissions:       toStringSlice(permissions),
+		DeniedPermissions: toStringSlice(deniedPermissions),
+	}
+	if err = connector.CreateServerPermissions(ctx, serverPermissionsModel); err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to create server permissions %v for [%s]", string(permissions_), principalName))
+	}
+
+	data.SetId(id)
+
+	logger.Info().Msgf("created server permissions %v for [%s]", string(permissions_), principalName)
+
+	return resourceServerPermissionsRead(ctx, data, meta)
+}
+
+func resourceServerPermissionsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(ctx, meta, "serverpermissions", "read")
+	logger.Debug().Msgf("Read %s", data.Id())
+
+	principalName := data.Get(principalNameProp).(string)
+
+	connector, err := getServerPermissionsConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	permissions, err := connector.GetServerPermissions(ctx, principalName)
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to read server permissions for [%s]", principalName))
+	}
+	if permissions == nil {
+		logger.Info().Msgf("login or server role [%s] does not exist", principalName)
+		data.SetId("")
+	} else {
+		if err = data.Set(principalNameProp, permissions.PrincipalName); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(principalIdProp, permissions.PrincipalID); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(permissionsProp, matchSetCase(data, permissionsProp, permissions.Permissions)); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(deniedPermissionsProp, matchSetCase(data, deniedPermissionsProp, permissions.DeniedPermissions)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceServerPermissionsDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(ctx, meta, "serverpermissions", "delete")
+	logger.Debug().Msgf("Delete %s", data.Id())
+
+	principalName := data.Get(principalNameProp).(string)
+	permissions := data.Get(permissionsProp).(*schema.Set).List()
+	deniedPermissions := data.Get(deniedPermissionsProp).(*schema.Set).List()
+
+	connector, err := getServerPermissionsConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serverPermissionsModel := &model.ServerPermissions{
+		PrincipalName:     principalName,
+		Permissions:       toStringSlice(permissions),
+		DeniedPermissions: toStringSlice(deniedPermissions),
+	}
+	if err = connector.DeleteServerPermissions(ctx, serverPermissionsModel); err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to delete server permissions for [%s]", principalName))
+	}
+
+	data.SetId("")
+
+	logger.Info().Msgf("deleted server permissions for [%s]", principalName)
+
+	return nil
+}
+
+func resourceServerPermissionsUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(ctx, meta, "serverpermissions", "update")
+	logger.Debug().Msgf("Update %s", data.Id())
+
+	principalName := data.Get(principalNameProp).(string)
+	permissions := data.Get(permissionsProp).(*schema.Set).List()
+	deniedPermissions := data.Get(deniedPermissionsProp).(*schema.Set).List()
+
+	// Store old values for all properties that might change
+	oldValues := make(map[string]interface{})
+	for _, prop := range []string{permissionsProp, deniedPermissionsProp} {
+		if data.HasChange(prop) {
+			oldValue, _ := data.GetChange(prop)
+			if oldSet, ok := oldValue.(*schema.Set); ok {
+				oldValues[prop] = oldSet.List()
+			}
+		}
+	}
+
+	connector, err := getServerPermissionsConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serverPermissionsModel := &model.ServerPermissions{
+		PrincipalName:     principalName,
+		Permissions:       toStringSlice(permissions),
+		DeniedPermissions: toStringSlice(deniedPermissions),
+	}
+	if err = connector.UpdateServerPermissions(ctx, serverPermissionsModel); err != nil {
+		// If update fails, revert all changed values in the state
+		for prop, oldValue := range oldValues {
+			if err := data.Set(prop, oldValue); err != nil {
+				logger.Error().Err(err).Msgf("Failed to revert %s state after update error", prop)
+			}
+		}
+		return diag.FromErr(errors.Wrapf(err, "unable to update server permissions for [%s]", principalName))
+	}
+
+	id, err := getServerPermissionsID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(id)
+
+	logger.Info().Msgf("updated server permissions for [%s]", principalName)
+
+	return resourceServerPermissionsRead(ctx, data, meta)
+}
+
+func resourceServerPermissionsImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	logger := loggerFromMeta(ctx, meta, "serverpermissions", "import")
+	logger.Debug().Msgf("Import %s", data.Id())
+
+	u, err := setServerFromId(meta, data)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(u.Path, "/")
+	if len(parts) != 3 || parts[1] != "server_permission" {
+		return nil, errors.New("invalid ID")
+	}
+	if err = data.Set(principalNameProp, parts[2]); err != nil {
+		return nil, err
+	}
+
+	id, err := getServerPermissionsID(meta, data)
+	if err != nil {
+		return nil, err
+	}
+	data.SetId(id)
+
+	principalName := data.Get(principalNameProp).(string)
+
+	connector, err := getServerPermissionsConnector(meta, data)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := connector.GetServerPermissions(ctx, principalName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to import server permissions for [%s]", principalName)
+	}
+
+	if permissions == nil {
+		return nil, errors.Errorf("login or server role [%s] does not exist", principalName)
+	}
+
+	if err = data.Set(principalIdProp, permissions.PrincipalID); err != nil {
+		return nil, err
+	}
+	if err = data.Set(permissionsProp, matchSetCase(data, permissionsProp, permissions.Permissions)); err != nil {
+		return nil, err
+	}
+	if err = data.Set(deniedPermissionsProp, matchSetCase(data, deniedPermissionsProp, permissions.DeniedPermissions)); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{data}, nil
+}
+
+func getServerPermissionsConnector(meta interface{}, data *schema.ResourceData) (ServerPermissionsConnector, error) {
+	provider := meta.(model.Provider)
+	connector, err := provider.GetConnector(serverProp, data)
+	if err != nil {
+		return nil, err
+	}
+	return connector.(ServerPermissionsConnector), nil
+}
@@ -0,0 +1,264 @@
+package mssql
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccServerPermissions_Local_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckServerPermissionsDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServerPermissions(t, "basic", "login", map[string]interface{}{"login_name": "server_perm_login", "login_password": "valueIsH8kd$¡", "permissions": "[\"VIEW SERVER STATE\", \"VIEW ANY DEFINITION\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerPermissionsExist("mssql_server_permissions.basic", Check{"permissions", "==", "VIEW ANY DEFINITION,VIEW SERVER STATE"}),
+					resource.TestCheckResourceAttr("mssql_server_permissions.basic", "id", "sqlserver://localhost:1433/server_permission/server_perm_login"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.basic", "principal_name", "server_perm_login"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.basic", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemAttr("mssql_server_permissions.basic", "permissions.*", "VIEW SERVER STATE"),
+					resource.TestCheckTypeSetElemAttr("mssql_server_permissions.basic", "permissions.*", "VIEW ANY DEFINITION"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.basic", "denied_permissions.#", "0"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.basic", "server.#", "1"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.basic", "server.0.host", "localhost"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.basic", "server.0.port", "1433"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.basic", "server.0.login.#", "1"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.basic", "server.0.login.0.username", os.Getenv("MSSQL_USERNAME")),
+					resource.TestCheckResourceAttr("mssql_server_permissions.basic", "server.0.login.0.password", os.Getenv("MSSQL_PASSWORD")),
+					resource.TestCheckResourceAttrSet("mssql_server_permissions.basic", "principal_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccServerPermissions_Local_Basic_Update(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckServerPermissionsDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServerPermissions(t, "update", "login", map[string]interface{}{"login_name": "server_perm_update", "login_password": "valueIsH8kd$¡", "permissions": "[\"VIEW SERVER STATE\", \"ALTER ANY EVENT SESSION\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerPermissionsExist("mssql_server_permissions.update", Check{"permissions", "==", "ALTER ANY EVENT SESSION,VIEW SERVER STATE"}, Check{"denied_permissions", "==", ""}),
+					resource.TestCheckResourceAttr("mssql_server_permissions.update", "permissions.#", "2"),
+				),
+			},
+			{
+				Config: testAccCheckServerPermissions(t, "update", "login", map[string]interface{}{"login_name": "server_perm_update", "login_password": "valueIsH8kd$¡", "permissions": "[\"VIEW SERVER STATE\"]", "denied_permissions": "[\"ALTER ANY EVENT SESSION\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerPermissionsExist("mssql_server_permissions.update", Check{"permissions", "==", "VIEW SERVER STATE"}, Check{"denied_permissions", "==", "ALTER ANY EVENT SESSION"}),
+					resource.TestCheckResourceAttr("mssql_server_permissions.update", "permissions.#", "1"),
+					resource.TestCheckTypeSetElemAttr("mssql_server_permissions.update", "permissions.*", "VIEW SERVER STATE"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.update", "denied_permissions.#", "1"),
+					resource.TestCheckTypeSetElemAttr("mssql_server_permissions.update", "denied_permissions.*", "ALTER ANY EVENT SESSION"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccServerPermissions_Local_ServerRole(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckServerPermissionsDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServerPermissions(t, "role", "login", map[string]interface{}{"role_name": "server_perm_role", "permissions": "[\"VIEW SERVER STATE\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerPermissionsExist("mssql_server_permissions.role", Check{"permissions", "==", "VIEW SERVER STATE"}),
+					resource.TestCheckResourceAttr("mssql_server_permissions.role", "principal_name", "server_perm_role"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.role", "permissions.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckServerPermissions(t *testing.T, name string, login string, data map[string]interface{}) string {
+	text := `
+			{{ if .login_name }}
+				resource "mssql_login" "{{ .name }}" {
+					server {
+						host = "{{ .host }}"
+						{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+					}
+					login_name = "{{ .login_name }}"
+					password   = "{{ .login_password }}"
+				}
+			{{ end }}
+			{{ if .role_name }}
+				resource "mssql_server_role" "{{ .name }}" {
+					server {
+						host = "{{ .host }}"
+						{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+					}
+					role_name = "{{ .role_name }}"
+				}
+			{{ end }}
+			resource "mssql_server_permissions" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				{{ if .login_name }}principal_name = mssql_login.{{ .name }}.login_name{{ else }}principal_name = mssql_server_role.{{ .name }}.role_name{{ end }}
+				{{ with .permissions }}permissions = {{ . }}{{ end }}
+				{{ with .denied_permissions }}denied_permissions = {{ . }}{{ end }}
+			}`
+
+	data["name"] = name
+	data["login"] = login
+	if login == "fedauth" || login == "msi" || login == "azure" {
+		data["host"] = os.Getenv("TF_ACC_SQL_SERVER")
+	} else if login == "login" {
+		data["host"] = "localhost"
+	} else {
+		t.Fatalf("login expected to be one of 'login', 'azure', 'msi', 'fedauth', got %s", login)
+	}
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return res
+}
+
+func testAccCheckServerPermissionsDestroy(state *terraform.State) error {
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "mssql_server_permissions" {
+			continue
+		}
+
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+
+		principalName := rs.Primary.Attributes["principal_name"]
+		permissions, err := connector.GetServerPermissions(principalName)
+		if err != nil {
+			return fmt.Errorf("expected no error, got %s", err)
+		}
+		if permissions != nil && (len(permissions.Permissions) > 0 || len(permissions.DeniedPermissions) > 0) {
+			return fmt.Errorf("server permissions still exist")
+		}
+	}
+	return nil
+}
+
+func testAccCheckServerPermissionsExist(resource string, checks ...Check) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("not found: %s", resource)
+		}
+		if rs.Type != "mssql_server_permissions" {
+			return fmt.Errorf("expected resource of type %s, got %s", "mssql_server_permissions", rs.Type)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no record ID is set")
+		}
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+
+		principalName := rs.Primary.Attributes["principal_name"]
+		permissions, err := connector.GetServerPermissions(principalName)
+		if err != nil {
+			return fmt.Errorf("expected no error, got %s", err)
+		}
+		if permissions == nil {
+			return fmt.Errorf("login or server role %s does not exist", principalName)
+		}
+
+		var actual interface{}
+		for _, check := range checks {
+			switch check.name {
+			case "principal_name":
+				actual = permissions.PrincipalName
+			case "permissions":
+				sort.Strings(permissions.Permissions)
+				actual = strings.Join(permissions.Permissions, ",")
+			case "denied_permissions":
+				sort.Strings(permissions.DeniedPermissions)
+				actual = strings.Join(permissions.DeniedPermissions, ",")
+			default:
+				return fmt.Errorf("unknown property %s", check.name)
+			}
+			if (check.op == "" || check.op == "==") && !equal(check.expected, actual) {
+				return fmt.Errorf("expected %s == %s, got %s", check.name, check.expected, actual)
+			}
+			if check.op == "!=" && equal(check.expected, actual) {
+				return fmt.Errorf("expected %s != %s, got %s", check.name, check.expected, actual)
+			}
+		}
+		return nil
+	}
+}
+
+// fakeServerPermissionsConnector returns permissions as sys.server_permissions
+// names them.
+type fakeServerPermissionsConnector struct {
+	permissions model.ServerPermissions
+}
+
+func (c *fakeServerPermissionsConnector) CreateServerPermissions(context.Context, *model.ServerPermissions) error {
+	return nil
+}
+
+func (c *fakeServerPermissionsConnector) GetServerPermissions(context.Context, string) (*model.ServerPermissions, error) {
+	permissions := c.permissions
+	return &permissions, nil
+}
+
+func (c *fakeServerPermissionsConnector) UpdateServerPermissions(context.Context, *model.ServerPermissions) error {
+	return nil
+}
+
+func (c *fakeServerPermissionsConnector) DeleteServerPermissions(context.Context, *model.ServerPermissions) error {
+	return nil
+}
+
+func TestServerPermissionsRead_KeepsConfiguredCase(t *testing.T) {
+	meta := fakeProvider{connector: &fakeServerPermissionsConnector{permissions: model.ServerPermissions{
+		PrincipalName:     "login",
+		Permissions:       []string{"VIEW SERVER STATE", "VIEW ANY DATABASE"},
+		DeniedPermissions: []string{"ALTER ANY LOGIN"},
+	}}}
+	data := schema.TestResourceDataRaw(t, resourceServerPermissions().Schema, map[string]interface{}{
+		"principal_name":     "login",
+		"permissions":        []interface{}{"view server state"},
+		"denied_permissions": []interface{}{"Alter Any Login"},
+	})
+	data.SetId("id")
+
+	if diags := resourceServerPermissionsRead(context.Background(), data, meta); diags.HasError() {
+		t.Fatal(diags)
+	}
+	for prop, expected := range map[string][]string{
+		permissionsProp:       {"VIEW ANY DATABASE", "view server state"},
+		deniedPermissionsProp: {"Alter Any Login"},
+	} {
+		actual := toStringSlice(data.Get(prop).(*schema.Set).List())
+		sort.Strings(actual)
+		if fmt.Sprint(actual) != fmt.Sprint(expected) {
+			t.Errorf("expected %s %v, got %v", prop, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package mssql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceServerRole() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceServerRoleCreate,
+		ReadContext:   resourceServerRoleRead,
+		UpdateContext: resourceServerRoleUpdate,
+		DeleteContext: resourceServerRoleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceServerRoleImport,
+		},
+		Schema: map[string]*schema.Schema{
+			serverProp: {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: getServerSchema(serverProp),
+				},
+			},
+			serverRefProp: getServerRefSchema(),
+			roleNameProp: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.SQLIdentifier,
+			},
+			ownerNameProp: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			ownerIdProp: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			principalIdProp: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: defaultTimeout,
+			Read:   defaultTimeout,
+			Update: defaultTimeout,
+			Delete: defaultTimeout,
+		},
+	}
+}
+
+type ServerRoleConnector interface {
+	CreateServerRole(ctx context.Context, roleName string, ownerName string) error
+	GetServerRole(ctx context.Context, roleName string) (*model.ServerRole, error)
+	UpdateServerRole(ctx context.Context, roleId int, roleName string, ownerName string) error
+	DeleteServerRole(ctx context.Context, roleName string) error
+}
+
+func resourceServerRoleCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(ctx, meta, "server_role", "create")
+	id, err := getServerRoleID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debug().Msgf("Create %s", id)
+
+	roleName := data.Get(roleNameProp).(string)
+	ownerName := data.Get(ownerNameProp).(string)
+
+	connector, err := getServerRoleConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = connector.CreateServerRole(ctx, roleName, ownerName); err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to create server role [%s]", roleName))
+	}
+
+	data.SetId(id)
+
+	logger.Info().Msgf("created server role [%s]", roleName)
+
+	return resourceServerRoleRead(ctx, data, meta)
+}
+
+func resourceServerRoleRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(ctx, meta, "server_role", "read")
+	logger.Debug().Msgf("Read %s", data.Id())
+
+	roleName := data.Get(roleNameProp).(string)
+
+	connector, err := getServerRoleConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	role, err := connector.GetServerRole(ctx, roleName)
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to get server role [%s]", roleName))
+	}
+
+	if role == nil {
+		logger.Info().Msgf("server role [%s] does not exist", roleName)
+		data.SetId("")
+	} else {
+		if err = data.Set(principalIdProp, role.RoleID); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(roleNameProp, role.RoleName); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(ownerNameProp, role.OwnerName); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(ownerIdProp, role.OwnerId); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	logger.Info().Msgf("read server role [%s]", roleName)
+
+	return nil
+}
+
+func resourceServerRoleDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(ctx, meta, "server_role", "delete")
+	logger.Debug().Msgf("Delete %s", data.Id())
+
+	roleName := data.Get(roleNameProp).(string)
+
+	connector, err := getServerRoleConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = connector.DeleteServerRole(ctx, roleName); err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to delete server role [%s]", roleName))
+	}
+
+	data.SetId("")
+
+	logger.Info().Msgf("deleted server role [%s]", roleName)
+
+	return nil
+}
+
+func resourceServerRoleUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(ctx, meta, "server_role", "update")
+	logger.Debug().Msgf("Update %s", data.Id())
+
+	roleId := data.Get(principalIdProp).(int)
+	roleName := data.Get(roleNameProp).(string)
+	ownerName := data.Get(ownerNameProp).(string)
+
+	// Store old values for all properties that might change
+	oldValues := make(map[string]interface{})
+	for _, prop := range []string{roleNameProp, ownerNameProp} {
+		if data.HasChange(prop) {
+			oldValue, _ := data.GetChange(prop)
+			oldValues[prop] = oldValue
+		}
+	}
+
+	connector, err := getServerRoleConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = connector.UpdateServerRole(ctx, roleId, roleName, ownerName); err != nil {
+		// If update fails, revert all changed values in the state
+		for prop, oldValue := range oldValues {
+			if err := data.Set(prop, oldValue); err != nil {
+				logger.Error().Err(err).Msgf("Failed to revert %s state after update error", prop)
+			}
+		}
+		return diag.FromErr(errors.Wrapf(err, "unable to update server role [%s]", roleName))
+	}
+
+	id, err := getServerRoleID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(id)
+
+	logger.Info().Msgf("updated server role [%s]", roleName)
+
+	return resourceServerRoleRead(ctx, data, meta)
+}
+
+func resourceServerRoleImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	logger := loggerFromMeta(ctx, meta, "server_role", "import")
+	logger.Debug().Msgf("Import %s", data.Id())
+
+	u, err := setServerFromId(meta, data)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(u.Path, "/")
+	if len(parts) != 3 || parts[1] != "server_role" {
+		return nil, errors.New("invalid ID")
+	}
+	if err = data.Set(roleNameProp, parts[2]); err != nil {
+		return nil, err
+	}
+
+	id, err := getServerRoleID(meta, data)
+	if err != nil {
+		return nil, err
+	}
+	data.SetId(id)
+
+	roleName := data.Get(roleNameProp).(string)
+
+	connector, err := getServerRoleConnector(meta, data)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := connector.GetServerRole(ctx, roleName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get server role [%s]", roleName)
+	}
+
+	if role == nil {
+		return nil, errors.Errorf("server role [%s] does not exist", roleName)
+	}
+
+	if err = data.Set(principalIdProp, role.RoleID); err != nil {
+		return nil, err
+	}
+	if err = data.Set(ownerNameProp, role.OwnerName); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{data}, nil
+}
+
+func getServerRoleConnector(meta interface{}, data *schema.ResourceData) (ServerRoleConnector, error) {
+	provider := meta.(model.Provider)
+	connector, err := provider.GetConnector(serverProp, data)
+	if err != nil {
+		return nil, err
+	}
+	return connector.(ServerRoleConnector), nil
+}
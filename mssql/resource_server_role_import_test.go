@@ -0,0 +1,31 @@
+package mssql
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccServerRole_Local_BasicImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckServerRoleDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServerRole(t, "test_import", "login", map[string]interface{}{"role_name": "test-server-role-import"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerRoleExists("mssql_server_role.test_import"),
+				),
+			},
+			{
+				ResourceName:      "mssql_server_role.test_import",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccImportStateId("mssql_server_role.test_import", false),
+			},
+		},
+	})
+}
@@ -0,0 +1,187 @@
+package mssql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceServerRoleMember() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceServerRoleMemberCreate,
+		ReadContext:   resourceServerRoleMemberRead,
+		DeleteContext: resourceServerRoleMemberDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceServerRoleMemberImport,
+		},
+		Schema: map[string]*schema.Schema{
+			serverProp: {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: getServerSchema(serverProp),
+				},
+			},
+			serverRefProp: getServerRefSchema(),
+			roleNameProp: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.SQLIdentifier,
+			},
+			memberNameProp: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.SQLIdentifier,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: defaultTimeout,
+			Read:   defaultTimeout,
+			Delete: defaultTimeout,
+		},
+	}
+}
+
+type ServerRoleMemberConnector interface {
+	AddServerRoleMember(ctx context.Context, roleName, memberName string) error
+	GetServerRoleMember(ctx context.Context, roleName, memberName string) (*model.ServerRoleMember, error)
+	DropServerRoleMember(ctx context.Context, roleName, memberName string) error
+}
+
+func resourceServerRoleMemberCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(ctx, meta, "server_role_member", "create")
+	id, err := getServerRoleMemberID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debug().Msgf("Create %s", id)
+
+	roleName := data.Get(roleNameProp).(string)
+	memberName := data.Get(memberNameProp).(string)
+
+	connector, err := getServerRoleMemberConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = connector.AddServerRoleMember(ctx, roleName, memberName); err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to add [%s] to server role [%s]", memberName, roleName))
+	}
+
+	data.SetId(id)
+
+	logger.Info().Msgf("added [%s] to server role [%s]", memberName, roleName)
+
+	return resourceServerRoleMemberRead(ctx, data, meta)
+}
+
+func resourceServerRoleMemberRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(ctx, meta, "server_role_member", "read")
+	logger.Debug().Msgf("Read %s", data.Id())
+
+	roleName := data.Get(roleNameProp).(string)
+	memberName := data.Get(memberNameProp).(string)
+
+	connector, err := getServerRoleMemberConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	member, err := connector.GetServerRoleMember(ctx, roleName, memberName)
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to read member [%s] of server role [%s]", memberName, roleName))
+	}
+	if member == nil {
+		logger.Info().Msgf("[%s] is not a member of server role [%s]", memberName, roleName)
+		data.SetId("")
+	}
+
+	return nil
+}
+
+func resourceServerRoleMemberDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(ctx, meta, "server_role_member", "delete")
+	logger.Debug().Msgf("Delete %s", data.Id())
+
+	roleName := data.Get(roleNameProp).(string)
+	memberName := data.Get(memberNameProp).(string)
+
+	connector, err := getServerRoleMemberConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = connector.DropServerRoleMember(ctx, roleName, memberName); err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to drop [%s] from server role [%s]", memberName, roleName))
+	}
+
+	data.SetId("")
+
+	logger.Info().Msgf("dropped [%s] from server role [%s]", memberName, roleName)
+
+	return nil
+}
+
+func resourceServerRoleMemberImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	logger := loggerFromMeta(ctx, meta, "server_role_member", "import")
+	logger.Debug().Msgf("Import %s", data.Id())
+
+	u, err := setServerFromId(meta, data)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(u.Path, "/")
+	if len(parts) != 5 || parts[1] != "server_role" || parts[3] != "member" {
+		return nil, errors.New("invalid ID")
+	}
+	if err = data.Set(roleNameProp, parts[2]); err != nil {
+		return nil, err
+	}
+	if err = data.Set(memberNameProp, parts[4]); err != nil {
+		return nil, err
+	}
+
+	id, err := getServerRoleMemberID(meta, data)
+	if err != nil {
+		return nil, err
+	}
+	data.SetId(id)
+
+	roleName := data.Get(roleNameProp).(string)
+	memberName := data.Get(memberNameProp).(string)
+
+	connector, err := getServerRoleMemberConnector(meta, data)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := connector.GetServerRoleMember(ctx, roleName, memberName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read member [%s] of server role [%s] for import", memberName, roleName)
+	}
+
+	if member == nil {
+		return nil, errors.Errorf("[%s] is not a member of server role [%s]", memberName, roleName)
+	}
+
+	return []*schema.ResourceData{data}, nil
+}
+
+func getServerRoleMemberConnector(meta interface{}, data *schema.ResourceData) (ServerRoleMemberConnector, error) {
+	provider := meta.(model.Provider)
+	connector, err := provider.GetConnector(serverProp, data)
+	if err != nil {
+		return nil, err
+	}
+	return connector.(ServerRoleMemberConnector), nil
+}
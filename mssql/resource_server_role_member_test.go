@@ -0,0 +1,148 @@
+package mssql
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccServerRoleMember_Local_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckServerRoleMemberDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServerRoleMember(t, "local_test", "login", map[string]interface{}{"role_name": "test_member_role", "login_name": "test_member_login", "login_password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerRoleMemberExists("mssql_server_role_member.local_test"),
+					resource.TestCheckResourceAttr("mssql_server_role_member.local_test", "id", "sqlserver://localhost:1433/server_role/test_member_role/member/test_member_login"),
+					resource.TestCheckResourceAttr("mssql_server_role_member.local_test", "role_name", "test_member_role"),
+					resource.TestCheckResourceAttr("mssql_server_role_member.local_test", "member_name", "test_member_login"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccServerRoleMember_Local_FixedRole(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckServerRoleMemberDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServerRoleMember(t, "local_test_fixed", "login", map[string]interface{}{"fixed_role_name": "dbcreator", "login_name": "test_member_login_fixed", "login_password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerRoleMemberExists("mssql_server_role_member.local_test_fixed"),
+					resource.TestCheckResourceAttr("mssql_server_role_member.local_test_fixed", "role_name", "dbcreator"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckServerRoleMember(t *testing.T, name string, login string, data map[string]interface{}) string {
+	text := `resource "mssql_login" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				login_name = "{{ .login_name }}"
+				password   = "{{ .login_password }}"
+			}
+			{{ if .role_name }}
+			resource "mssql_server_role" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				role_name = "{{ .role_name }}"
+			}
+			{{ end }}
+			resource "mssql_server_role_member" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				{{ if .role_name }}
+				role_name   = mssql_server_role.{{ .name }}.role_name
+				{{ else }}
+				role_name   = "{{ .fixed_role_name }}"
+				{{ end }}
+				member_name = mssql_login.{{ .name }}.login_name
+			}`
+
+	data["name"] = name
+	data["login"] = login
+	if login == "fedauth" || login == "msi" || login == "azure" {
+		data["host"] = os.Getenv("TF_ACC_SQL_SERVER")
+	} else if login == "login" {
+		data["host"] = "localhost"
+	} else {
+		t.Fatalf("login expected to be one of 'login', 'azure', 'msi', 'fedauth', got %s", login)
+	}
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return res
+}
+
+func testAccCheckServerRoleMemberDestroy(state *terraform.State) error {
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "mssql_server_role_member" {
+			continue
+		}
+
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+
+		roleName := rs.Primary.Attributes["role_name"]
+		memberName := rs.Primary.Attributes["member_name"]
+		member, err := connector.GetServerRoleMember(roleName, memberName)
+		if member != nil {
+			return fmt.Errorf("server role member still exists")
+		}
+		if err != nil {
+			return fmt.Errorf("expected no error, got %s", err)
+		}
+	}
+	return nil
+}
+
+func testAccCheckServerRoleMemberExists(resource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("not found: %s", resource)
+		}
+		if rs.Type != "mssql_server_role_member" {
+			return fmt.Errorf("expected resource of type %s, got %s", "mssql_server_role_member", rs.Type)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no record ID is set")
+		}
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+		roleName := rs.Primary.Attributes["role_name"]
+		memberName := rs.Primary.Attributes["member_name"]
+		member, err := connector.GetServerRoleMember(roleName, memberName)
+		if err != nil {
+			return fmt.Errorf("error: %s", err)
+		}
+		if member == nil {
+			return fmt.Errorf("%s is not a member of server role %s", memberName, roleName)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,217 @@
+package mssql
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccServerRole_Local_Basic_Create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckServerRoleDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServerRole(t, "local_test_create", "login", map[string]interface{}{"role_name": "test_server_role_create"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerRoleExists("mssql_server_role.local_test_create", Check{"owner_name", "==", os.Getenv("MSSQL_USERNAME")}),
+					resource.TestCheckResourceAttr("mssql_server_role.local_test_create", "id", "sqlserver://localhost:1433/server_role/test_server_role_create"),
+					resource.TestCheckResourceAttr("mssql_server_role.local_test_create", "role_name", "test_server_role_create"),
+					resource.TestCheckResourceAttr("mssql_server_role.local_test_create", "owner_name", os.Getenv("MSSQL_USERNAME")),
+					resource.TestCheckResourceAttr("mssql_server_role.local_test_create", "server.#", "1"),
+					resource.TestCheckResourceAttr("mssql_server_role.local_test_create", "server.0.host", "localhost"),
+					resource.TestCheckResourceAttr("mssql_server_role.local_test_create", "server.0.port", "1433"),
+					resource.TestCheckResourceAttr("mssql_server_role.local_test_create", "server.0.login.#", "1"),
+					resource.TestCheckResourceAttr("mssql_server_role.local_test_create", "server.0.login.0.username", os.Getenv("MSSQL_USERNAME")),
+					resource.TestCheckResourceAttr("mssql_server_role.local_test_create", "server.0.login.0.password", os.Getenv("MSSQL_PASSWORD")),
+					resource.TestCheckResourceAttrSet("mssql_server_role.local_test_create", "principal_id"),
+					resource.TestCheckResourceAttrSet("mssql_server_role.local_test_create", "owning_principal_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccServerRole_Local_Basic_Create_owner(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckServerRoleDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServerRole(t, "test_create_auth", "login", map[string]interface{}{"role_name": "test_server_role_auth", "owner_name": "server_role_owner", "login_name": "server_role_owner", "login_password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerRoleExists("mssql_server_role.test_create_auth", Check{"owner_name", "==", "server_role_owner"}),
+					resource.TestCheckResourceAttr("mssql_server_role.test_create_auth", "role_name", "test_server_role_auth"),
+					resource.TestCheckResourceAttr("mssql_server_role.test_create_auth", "owner_name", "server_role_owner"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccServerRole_Local_Basic_Update(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckServerRoleDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServerRole(t, "local_test_update", "login", map[string]interface{}{"role_name": "test_server_role_pre"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerRoleExists("mssql_server_role.local_test_update", Check{"role_name", "==", "test_server_role_pre"}),
+					resource.TestCheckResourceAttr("mssql_server_role.local_test_update", "role_name", "test_server_role_pre"),
+				),
+			},
+			{
+				Config: testAccCheckServerRole(t, "local_test_update", "login", map[string]interface{}{"role_name": "test_server_role_post"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerRoleExists("mssql_server_role.local_test_update", Check{"role_name", "==", "test_server_role_post"}),
+					resource.TestCheckResourceAttr("mssql_server_role.local_test_update", "role_name", "test_server_role_post"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccServerRole_Local_Basic_Update_owner(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckServerRoleDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServerRole(t, "local_test_update_owner", "login", map[string]interface{}{"role_name": "test_server_role_owner", "owner_name": "server_role_owner_pre", "login_name": "server_role_owner_pre", "login_password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerRoleExists("mssql_server_role.local_test_update_owner", Check{"owner_name", "==", "server_role_owner_pre"}),
+					resource.TestCheckResourceAttr("mssql_server_role.local_test_update_owner", "owner_name", "server_role_owner_pre"),
+				),
+			},
+			{
+				Config: testAccCheckServerRole(t, "local_test_update_owner", "login", map[string]interface{}{"role_name": "test_server_role_owner", "owner_name": "server_role_owner_post", "login_name": "server_role_owner_post", "login_password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerRoleExists("mssql_server_role.local_test_update_owner", Check{"owner_name", "==", "server_role_owner_post"}),
+					resource.TestCheckResourceAttr("mssql_server_role.local_test_update_owner", "role_name", "test_server_role_owner"),
+					resource.TestCheckResourceAttr("mssql_server_role.local_test_update_owner", "owner_name", "server_role_owner_post"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckServerRole(t *testing.T, name string, login string, data map[string]interface{}) string {
+	text := `
+			{{ if .login_name }}
+				resource "mssql_login" "{{ .name }}" {
+					server {
+						host = "{{ .host }}"
+						{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+					}
+					login_name = "{{ .login_name }}"
+					password   = "{{ .login_password }}"
+				}
+			{{ end }}
+			resource "mssql_server_role" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				role_name = "{{ .role_name }}"
+				{{ with .owner_name }}owner_name = "{{ . }}"{{ end }}
+				{{ if .login_name }}
+				depends_on = [mssql_login.{{ .name }}]
+				{{ end }}
+			}`
+
+	data["name"] = name
+	data["login"] = login
+	if login == "fedauth" || login == "msi" || login == "azure" {
+		data["host"] = os.Getenv("TF_ACC_SQL_SERVER")
+	} else if login == "login" {
+		data["host"] = "localhost"
+	} else {
+		t.Fatalf("login expected to be one of 'login', 'azure', 'msi', 'fedauth', got %s", login)
+	}
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return res
+}
+
+func testAccCheckServerRoleDestroy(state *terraform.State) error {
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "mssql_server_role" {
+			continue
+		}
+
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+
+		roleName := rs.Primary.Attributes["role_name"]
+		role, err := connector.GetServerRole(roleName)
+		if role != nil {
+			return fmt.Errorf("server role still exists")
+		}
+		if err != nil {
+			return fmt.Errorf("expected no error, got %s", err)
+		}
+	}
+	return nil
+}
+
+func testAccCheckServerRoleExists(resource string, checks ...Check) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("not found: %s", resource)
+		}
+		if rs.Type != "mssql_server_role" {
+			return fmt.Errorf("expected resource of type %s, got %s", "mssql_server_role", rs.Type)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no record ID is set")
+		}
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+		roleName := rs.Primary.Attributes["role_name"]
+		role, err := connector.GetServerRole(roleName)
+		if err != nil {
+			return fmt.Errorf("error: %s", err)
+		}
+		if role == nil {
+			return fmt.Errorf("server role %s does not exist", roleName)
+		}
+
+		var actual interface{}
+		for _, check := range checks {
+			switch check.name {
+			case "role_name":
+				actual = role.RoleName
+			case "owner_name":
+				actual = role.OwnerName
+			default:
+				return fmt.Errorf("unknown property %s", check.name)
+			}
+			if (check.op == "" || check.op == "==") && !equal(check.expected, actual) {
+				return fmt.Errorf("expected %s == %s, got %s", check.name, check.expected, actual)
+			}
+			if check.op == "!=" && equal(check.expected, actual) {
+				return fmt.Errorf("expected %s != %s, got %s", check.name, check.expected, actual)
+			}
+		}
+		return nil
+	}
+}
@@ -25,11 +25,12 @@ func resourceUser() *schema.Resource {
 			serverProp: {
 				Type:     schema.TypeList,
 				MaxItems: 1,
-				Required: true,
+				Optional: true,
 				Elem: &schema.Resource{
 					Schema: getServerSchema(serverProp),
 				},
 			},
+			serverRefProp: getServerRefSchema(),
 			ignoreDeletionProp: {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -105,11 +106,13 @@ func resourceUser() *schema.Resource {
 			rolesProp: {
 				Type:     schema.TypeSet,
 				Optional: true,
+				Computed: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 		},
+		CustomizeDiff: resourceUserCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Create: defaultTimeout,
 			Read:   defaultTimeout,
@@ -119,6 +122,23 @@ func resourceUser() *schema.Resource {
 	}
 }
 
+// resourceUserCustomizeDiff rejects a default language on Azure SQL Database,
+// where it cannot be set for contained or external users.
+func resourceUserCustomizeDiff(ctx context.Context, data *schema.ResourceDiff, meta interface{}) error {
+	if err := customizeDiffEmptySet(data, rolesProp); err != nil {
+		return err
+	}
+	if data.Get(defaultLanguageProp).(string) == "" {
+		return nil
+	}
+
+	capabilities := planServerCapabilities(ctx, meta, data, "user")
+	if capabilities != nil && capabilities.IsAzureSQLDatabase() {
+		return errors.Errorf("DEFAULT_LANGUAGE not supported on Azure SQL Database, remove %s", defaultLanguageProp)
+	}
+	return nil
+}
+
 type UserConnector interface {
 	CreateUser(ctx context.Context, database string, user *model.User) error
 	GetUser(ctx context.Context, database, username string) (*model.User, error)
@@ -128,8 +148,12 @@ type UserConnector interface {
 }
 
 func resourceUserCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "user", "create")
-	logger.Debug().Msgf("Create %s", getUserID(data))
+	logger := loggerFromMeta(ctx, meta, "user", "create")
+	id, err := getUserID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logger.Debug().Msgf("Create %s", id)
 
 	database := data.Get(databaseProp).(string)
 	username := data.Get(usernameProp).(string)
@@ -160,7 +184,7 @@ func resourceUserCreate(ctx context.Context, data *schema.ResourceData, meta int
 		return diag.FromErr(errors.Wrapf(err, "unable to create user [%s].[%s]", database, username))
 	}
 
-	data.SetId(getUserID(data))
+	data.SetId(id)
 
 	logger.Info().Msgf("created user [%s].[%s]", database, username)
 
@@ -168,7 +192,7 @@ func resourceUserCreate(ctx context.Context, data *schema.ResourceData, meta int
 }
 
 func resourceUserRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "user", "read")
+	logger := loggerFromMeta(ctx, meta, "user", "read")
 	logger.Debug().Msgf("Read %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -228,14 +252,13 @@ func resourceUserRead(ctx context.Context, data *schema.ResourceData, meta inter
 }
 
 func resourceUserUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	logger := loggerFromMeta(meta, "user", "update")
+	logger := loggerFromMeta(ctx, meta, "user", "update")
 	logger.Debug().Msgf("Update %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
 	username := data.Get(usernameProp).(string)
 	defaultSchema := data.Get(defaultSchemaProp).(string)
 	defaultLanguage := data.Get(defaultLanguageProp).(string)
-	roles := data.Get(rolesProp).(*schema.Set).List()
 
 	// Store old values for all properties that might change
 	oldValues := make(map[string]interface{})
@@ -262,7 +285,12 @@ func resourceUserUpdate(ctx context.Context, data *schema.ResourceData, meta int
 		Username:        username,
 		DefaultSchema:   defaultSchema,
 		DefaultLanguage: defaultLanguage,
-		Roles:           toStringSlice(roles),
+	}
+
+	// Only manage the roles when they change, so memberships added outside of
+	// the resource are kept while roles is omitted
+	if data.HasChange(rolesProp) {
+		user.Roles = toStringSlice(data.Get(rolesProp).(*schema.Set).List())
 	}
 
 	// Only include password in the update if it has changed
@@ -280,7 +308,11 @@ func resourceUserUpdate(ctx context.Context, data *schema.ResourceData, meta int
 		return diag.FromErr(errors.Wrapf(err, "unable to update user [%s].[%s]", database, username))
 	}
 
-	data.SetId(getUserID(data))
+	id, err := getUserID(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(id)
 
 	logger.Info().Msgf("updated user [%s].[%s]", database, username)
 
@@ -294,7 +326,7 @@ func resourceUserDelete(ctx context.Context, data *schema.ResourceData, meta int
     return nil
   }
 
-  logger := loggerFromMeta(meta, "user", "delete")
+  logger := loggerFromMeta(ctx, meta, "user", "delete")
 	logger.Debug().Msgf("Delete %s", data.Id())
 
 	database := data.Get(databaseProp).(string)
@@ -318,16 +350,13 @@ func resourceUserDelete(ctx context.Context, data *schema.ResourceData, meta int
 }
 
 func resourceUserImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	logger := loggerFromMeta(meta, "user", "import")
+	logger := loggerFromMeta(ctx, meta, "user", "import")
 	logger.Debug().Msgf("Import %s", data.Id())
 
-	server, u, err := serverFromId(data.Id())
+	u, err := setServerFromId(meta, data)
 	if err != nil {
 		return nil, err
 	}
-	if err = data.Set(serverProp, server); err != nil {
-		return nil, err
-	}
 
 	parts := strings.Split(u.Path, "/")
 	if len(parts) != 4 {
@@ -340,7 +369,11 @@ func resourceUserImport(ctx context.Context, data *schema.ResourceData, meta int
 		return nil, err
 	}
 
-	data.SetId(getUserID(data))
+	id, err := getUserID(meta, data)
+	if err != nil {
+		return nil, err
+	}
+	data.SetId(id)
 
 	database := data.Get(databaseProp).(string)
 	username := data.Get(usernameProp).(string)
@@ -2,25 +2,37 @@ package mssql
 
 import (
 	"errors"
+	"fmt"
 	"net"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const DefaultPort = "1433"
 
+var loginMethods = []string{
+	"login",
+	"azure_login",
+	"azuread_default_chain_auth",
+	"azuread_managed_identity_auth",
+	"azuread_workload_identity_auth",
+	"azuread_access_token_auth",
+}
+
 func getServerSchema(prefix string) map[string]*schema.Schema {
 	if len(prefix) > 0 {
 		prefix = prefix + ".0."
 	}
-	var LoginMethods = []string{
-		prefix + "login",
-		prefix + "azure_login",
-		prefix + "azuread_default_chain_auth",
-		prefix + "azuread_managed_identity_auth",
+	var LoginMethods = make([]string, len(loginMethods))
+	for i, method := range loginMethods {
+		LoginMethods[i] = prefix + method
 	}
 	return map[string]*schema.Schema{
 		"host": {
@@ -37,6 +49,56 @@ func getServerSchema(prefix string) map[string]*schema.Schema {
 			ForceNew: true,
 			Default:  DefaultPort,
 		},
+		"instance": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+		},
+		"encrypt": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"disable", "false", "true", "strict"}, false),
+		},
+		"trust_server_certificate": {
+			Type:         schema.TypeBool,
+			Optional:     true,
+			Default:      false,
+			RequiredWith: []string{prefix + "encrypt"},
+		},
+		"host_name_in_certificate": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			RequiredWith: []string{prefix + "encrypt"},
+		},
+		"ca_certificate_path": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			RequiredWith: []string{prefix + "encrypt"},
+		},
+		"app_name": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"application_intent": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"ReadWrite", "ReadOnly"}, false),
+		},
+		"multi_subnet_failover": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"packet_size": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntBetween(512, 32767),
+		},
+		"connect_timeout": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validate.Duration,
+		},
 		"login": {
 			Type:         schema.TypeList,
 			MaxItems:     1,
@@ -76,10 +138,23 @@ func getServerSchema(prefix string) map[string]*schema.Schema {
 						DefaultFunc: schema.EnvDefaultFunc("MSSQL_CLIENT_ID", nil),
 					},
 					"client_secret": {
+						Type:          schema.TypeString,
+						Optional:      true,
+						Sensitive:     true,
+						DefaultFunc:   schema.EnvDefaultFunc("MSSQL_CLIENT_SECRET", nil),
+						ConflictsWith: []string{prefix + "azure_login.0.client_certificate_path"},
+					},
+					"client_certificate_path": {
+						Type:          schema.TypeString,
+						Optional:      true,
+						DefaultFunc:   schema.EnvDefaultFunc("MSSQL_CLIENT_CERTIFICATE_PATH", nil),
+						ConflictsWith: []string{prefix + "azure_login.0.client_secret"},
+					},
+					"client_certificate_password": {
 						Type:        schema.TypeString,
-						Required:    true,
+						Optional:    true,
 						Sensitive:   true,
-						DefaultFunc: schema.EnvDefaultFunc("MSSQL_CLIENT_SECRET", nil),
+						DefaultFunc: schema.EnvDefaultFunc("MSSQL_CLIENT_CERTIFICATE_PASSWORD", nil),
 					},
 				},
 			},
@@ -105,9 +180,180 @@ func getServerSchema(prefix string) map[string]*schema.Schema {
 				},
 			},
 		},
+		"azuread_workload_identity_auth": {
+			Type:         schema.TypeList,
+			MaxItems:     1,
+			Optional:     true,
+			ExactlyOneOf: LoginMethods,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"tenant_id": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						DefaultFunc: schema.EnvDefaultFunc("MSSQL_TENANT_ID", nil),
+					},
+					"client_id": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						DefaultFunc: schema.EnvDefaultFunc("MSSQL_CLIENT_ID", nil),
+					},
+					"token_file_path": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						DefaultFunc: schema.EnvDefaultFunc("MSSQL_FEDERATED_TOKEN_FILE", nil),
+					},
+				},
+			},
+		},
+		"azuread_access_token_auth": {
+			Type:         schema.TypeList,
+			MaxItems:     1,
+			Optional:     true,
+			ExactlyOneOf: LoginMethods,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"access_token": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Sensitive:   true,
+						DefaultFunc: schema.EnvDefaultFunc("MSSQL_ACCESS_TOKEN", nil),
+					},
+				},
+			},
+		},
 	}
 }
 
+// getServerProfileSchema returns the schema of a named server profile in the
+// provider configuration. Profiles are a list, so ExactlyOneOf and RequiredWith
+// cannot address their attributes; providerConfigure checks the login method
+// and the TLS settings instead.
+func getServerProfileSchema() map[string]*schema.Schema {
+	s := getServerSchema(serverProfileProp)
+	for _, v := range s {
+		v.ExactlyOneOf = nil
+		v.RequiredWith = nil
+	}
+	azureLogin := s["azure_login"].Elem.(*schema.Resource).Schema
+	for _, v := range azureLogin {
+		v.ConflictsWith = nil
+	}
+	s[nameProp] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	return s
+}
+
+func getServerRefSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		ForceNew:      true,
+		ConflictsWith: []string{serverProp},
+	}
+}
+
+func countLoginMethods(server map[string]interface{}) int {
+	count := 0
+	for _, method := range loginMethods {
+		if block, ok := server[method].([]interface{}); ok && len(block) > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// checkTLSSettings returns an error when server sets TLS attributes that only
+// take effect together with encrypt, without it.
+func checkTLSSettings(server map[string]interface{}) error {
+	if encrypt, _ := server["encrypt"].(string); encrypt != "" {
+		return nil
+	}
+	if trust, _ := server["trust_server_certificate"].(bool); trust {
+		return errors.New("trust_server_certificate requires encrypt")
+	}
+	for _, attr := range []string{"host_name_in_certificate", "ca_certificate_path"} {
+		if value, _ := server[attr].(string); value != "" {
+			return fmt.Errorf("%s requires encrypt", attr)
+		}
+	}
+	return nil
+}
+
+// setServerFromId sets the server of an imported resource from its ID. An ID
+// with a server_ref query parameter selects a provider server profile instead
+// of an inline server block, and an ID of the provider default server without
+// credentials leaves both unset, as the configuration of such a resource
+// does.
+func setServerFromId(meta interface{}, data *schema.ResourceData) (*url.URL, error) {
+	u, err := url.Parse(data.Id())
+	if err != nil {
+		return nil, err
+	}
+	if ref := u.Query().Get(serverRefProp); ref != "" {
+		if err = data.Set(serverRefProp, ref); err != nil {
+			return nil, err
+		}
+		return u, nil
+	}
+	if isDefaultServer(meta, data, u) {
+		return u, nil
+	}
+
+	server, u, err := serverFromId(data.Id())
+	if err != nil {
+		return nil, err
+	}
+	if err = data.Set(serverProp, server); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// idCredentialParams are the query parameters of an ID that select the login
+// of the server, and so its inline server block.
+var idCredentialParams = []string{
+	"auth", "azure", "username", "password", "tenant_id", "client_id", "client_secret",
+	"client_certificate_path", "client_certificate_password", "user_id", "token_file_path",
+}
+
+// isDefaultServer reports whether the ID u names the host, port and instance
+// of the provider default server, without credentials of its own.
+func isDefaultServer(meta interface{}, data *schema.ResourceData, u *url.URL) bool {
+	if u.Scheme != "sqlserver" && u.Scheme != "mssql" {
+		return false
+	}
+	values := u.Query()
+	for _, param := range idCredentialParams {
+		if values.Has(param) {
+			return false
+		}
+	}
+	// Without a server block or server_ref, this is the provider default
+	server, err := meta.(model.Provider).GetServer(serverProp, data)
+	if err != nil {
+		return false
+	}
+	host, port, err := hostPort(u)
+	if err != nil {
+		return false
+	}
+	defaultHost, _ := server["host"].(string)
+	defaultPort, _ := server["port"].(string)
+	defaultInstance, _ := server["instance"].(string)
+	return strings.EqualFold(host, defaultHost) && port == defaultPort && strings.EqualFold(values.Get("instance"), defaultInstance)
+}
+
+// hostPort returns the host and port of the ID u, the port defaulting to
+// DefaultPort.
+func hostPort(u *url.URL) (string, string, error) {
+	if !strings.ContainsRune(u.Host, ':') {
+		return u.Host, DefaultPort, nil
+	}
+	return net.SplitHostPort(u.Host)
+}
+
 func serverFromId(id string) ([]map[string]interface{}, *url.URL, error) {
 	u, err := url.Parse(id)
 	if err != nil {
@@ -118,22 +364,45 @@ func serverFromId(id string) ([]map[string]interface{}, *url.URL, error) {
 		return nil, nil, errors.New("invalid schema in ID")
 	}
 
-	host := u.Host
-	port := DefaultPort
+	host, port, err := hostPort(u)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := u.Query()
+
+	trustServerCertificate, _ := strconv.ParseBool(values.Get("trust_server_certificate"))
+	multiSubnetFailover, _ := strconv.ParseBool(values.Get("multi_subnet_failover"))
+	packetSize, _ := strconv.Atoi(values.Get("packet_size"))
 
-	if strings.ContainsRune(host, ':') {
-		var err error
-		if host, port, err = net.SplitHostPort(u.Host); err != nil {
+	server := map[string]interface{}{
+		"host":                     host,
+		"port":                     port,
+		"instance":                 values.Get("instance"),
+		"encrypt":                  values.Get("encrypt"),
+		"trust_server_certificate": trustServerCertificate,
+		"host_name_in_certificate": values.Get("host_name_in_certificate"),
+		"ca_certificate_path":      values.Get("ca_certificate_path"),
+		"app_name":                 values.Get("app_name"),
+		"application_intent":       values.Get("application_intent"),
+		"multi_subnet_failover":    multiSubnetFailover,
+		"packet_size":              packetSize,
+		"connect_timeout":          values.Get("connect_timeout"),
+	}
+
+	if auth := values.Get("auth"); auth != "" {
+		block, err := getAuthBlock(auth, values)
+		if err != nil {
 			return nil, nil, err
 		}
+		server[auth] = block
+		return []map[string]interface{}{server}, u, nil
 	}
 
-	values := u.Query()
-
 	login, loginInValues := getLogin(values)
 	azureLogin, azureInValues := getAzureLogin(values)
 	if login == nil && azureLogin == nil {
-		return nil, nil, errors.New("neither login nor azure login specified")
+		return nil, nil, fmt.Errorf("neither login nor azure login specified, use the auth parameter to select one of %s", strings.Join(loginMethods, ", "))
 	}
 	if loginInValues && azureInValues {
 		return nil, nil, errors.New("both login and azure login specified in resource")
@@ -151,12 +420,56 @@ func serverFromId(id string) ([]map[string]interface{}, *url.URL, error) {
 		}
 	}
 
-	return []map[string]interface{}{{
-		"host":        host,
-		"port":        port,
-		"login":       login,
-		"azure_login": azureLogin,
-	}}, u, nil
+	server["login"] = login
+	server["azure_login"] = azureLogin
+	return []map[string]interface{}{server}, u, nil
+}
+
+// getAuthBlock returns the block of the login method selected by the auth
+// parameter of an ID, with its attributes taken from the ID or the
+// environment variables of the matching schema attributes.
+func getAuthBlock(auth string, values url.Values) ([]map[string]interface{}, error) {
+	switch auth {
+	case "login":
+		if login, _ := getLogin(values); login != nil {
+			return login, nil
+		}
+		return nil, errors.New("login requires a username and password, e.g. from MSSQL_USERNAME and MSSQL_PASSWORD")
+	case "azure_login":
+		if azureLogin, _ := getAzureLogin(values); azureLogin != nil {
+			return azureLogin, nil
+		}
+		return nil, errors.New("azure_login requires a tenant ID, client ID and client secret or certificate, e.g. from MSSQL_TENANT_ID, MSSQL_CLIENT_ID and MSSQL_CLIENT_SECRET")
+	case "azuread_default_chain_auth":
+		return []map[string]interface{}{{}}, nil
+	case "azuread_managed_identity_auth":
+		return []map[string]interface{}{{
+			"user_id": values.Get("user_id"),
+		}}, nil
+	case "azuread_workload_identity_auth":
+		return []map[string]interface{}{{
+			"tenant_id":       valueOrEnv(values, "tenant_id", "MSSQL_TENANT_ID"),
+			"client_id":       valueOrEnv(values, "client_id", "MSSQL_CLIENT_ID"),
+			"token_file_path": valueOrEnv(values, "token_file_path", "MSSQL_FEDERATED_TOKEN_FILE"),
+		}}, nil
+	case "azuread_access_token_auth":
+		// The token is only taken from the environment, so it does not end up in the ID
+		token := os.Getenv("MSSQL_ACCESS_TOKEN")
+		if token == "" {
+			return nil, errors.New("azuread_access_token_auth requires the MSSQL_ACCESS_TOKEN environment variable")
+		}
+		return []map[string]interface{}{{
+			"access_token": token,
+		}}, nil
+	}
+	return nil, fmt.Errorf("unknown auth [%s] in ID, expected one of %s", auth, strings.Join(loginMethods, ", "))
+}
+
+func valueOrEnv(values url.Values, key, env string) string {
+	if v := values.Get(key); v != "" {
+		return v
+	}
+	return os.Getenv(env)
 }
 
 func getLogin(values url.Values) ([]map[string]interface{}, bool) {
@@ -203,20 +516,31 @@ func getAzureLogin(values url.Values) ([]map[string]interface{}, bool) {
 		inValues = true
 	}
 
+	// The credential is only taken from the environment when the ID has
+	// neither, so an exported secret is not combined with a certificate
 	clientSecret := values.Get("client_secret")
-	if clientSecret == "" {
+	clientCertificatePath := values.Get("client_certificate_path")
+	if clientSecret == "" && clientCertificatePath == "" {
 		clientSecret = os.Getenv("MSSQL_CLIENT_SECRET")
+		clientCertificatePath = os.Getenv("MSSQL_CLIENT_CERTIFICATE_PATH")
 	} else {
 		inValues = true
 	}
 
-	if tenantId == "" || clientId == "" || clientSecret == "" {
+	clientCertificatePassword := values.Get("client_certificate_password")
+	if clientCertificatePassword == "" {
+		clientCertificatePassword = os.Getenv("MSSQL_CLIENT_CERTIFICATE_PASSWORD")
+	}
+
+	if tenantId == "" || clientId == "" || (clientSecret == "" && clientCertificatePath == "") {
 		return nil, false
 	}
 
 	return []map[string]interface{}{{
-		"tenant_id":     tenantId,
-		"client_id":     clientId,
-		"client_secret": clientSecret,
+		"tenant_id":                   tenantId,
+		"client_id":                   clientId,
+		"client_secret":               clientSecret,
+		"client_certificate_path":     clientCertificatePath,
+		"client_certificate_password": clientCertificatePassword,
 	}}, inValues
 }
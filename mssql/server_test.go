@@ -0,0 +1,254 @@
+package mssql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestServerSchema_TLSRequiresEncrypt(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		tls      map[string]interface{}
+		expected string
+	}{
+		{"trust_server_certificate", map[string]interface{}{"trust_server_certificate": false}, "trust_server_certificate"},
+		{"host_name_in_certificate", map[string]interface{}{"host_name_in_certificate": "sql.example.com"}, "host_name_in_certificate"},
+		{"ca_certificate_path", map[string]interface{}{"ca_certificate_path": "/etc/ssl/ca.pem"}, "ca_certificate_path"},
+		{"with encrypt", map[string]interface{}{"encrypt": "true", "trust_server_certificate": false, "host_name_in_certificate": "sql.example.com"}, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := map[string]interface{}{
+				"host":  "localhost",
+				"login": []interface{}{map[string]interface{}{"username": "sa", "password": "valueIsH8kd$¡"}},
+			}
+			for k, v := range tc.tls {
+				server[k] = v
+			}
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"login_name": "test",
+				"password":   "valueIsH8kd$¡",
+				"server":     []interface{}{server},
+			})
+
+			diags := resourceLogin().Validate(config)
+			if tc.expected == "" {
+				if diags.HasError() {
+					t.Errorf("expected no error, got %v", diags)
+				}
+				return
+			}
+			found := false
+			for _, d := range diags {
+				found = found || strings.Contains(d.Summary+d.Detail, tc.expected)
+			}
+			if !found {
+				t.Errorf("expected an error about %s, got %v", tc.expected, diags)
+			}
+		})
+	}
+}
+
+func TestCheckTLSSettings(t *testing.T) {
+	for _, tc := range []struct {
+		server map[string]interface{}
+		valid  bool
+	}{
+		{map[string]interface{}{}, true},
+		{map[string]interface{}{"encrypt": "strict", "trust_server_certificate": true, "ca_certificate_path": "/etc/ssl/ca.pem"}, true},
+		{map[string]interface{}{"trust_server_certificate": true}, false},
+		{map[string]interface{}{"host_name_in_certificate": "sql.example.com"}, false},
+		{map[string]interface{}{"encrypt": "", "ca_certificate_path": "/etc/ssl/ca.pem"}, false},
+	} {
+		if err := checkTLSSettings(tc.server); (err == nil) != tc.valid {
+			t.Errorf("expected %v to be valid: %v, got %v", tc.server, tc.valid, err)
+		}
+	}
+}
+
+func TestServerSchema_AzureLoginCredentialConflicts(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"login_name": "test",
+		"password":   "valueIsH8kd$¡",
+		"server": []interface{}{map[string]interface{}{
+			"host": "localhost",
+			"azure_login": []interface{}{map[string]interface{}{
+				"tenant_id":               "tenant",
+				"client_id":               "client",
+				"client_secret":           "secret",
+				"client_certificate_path": "/etc/ssl/client.pem",
+			}},
+		}},
+	})
+
+	diags := resourceLogin().Validate(config)
+	found := false
+	for _, d := range diags {
+		found = found || strings.Contains(d.Summary+d.Detail, "conflicts with")
+	}
+	if !found {
+		t.Errorf("expected client_secret and client_certificate_path to conflict, got %v", diags)
+	}
+}
+
+func TestServerFromId_Auth(t *testing.T) {
+	for _, env := range []string{"MSSQL_USERNAME", "MSSQL_PASSWORD", "MSSQL_TENANT_ID", "MSSQL_CLIENT_ID", "MSSQL_CLIENT_SECRET",
+		"MSSQL_CLIENT_CERTIFICATE_PATH", "MSSQL_CLIENT_CERTIFICATE_PASSWORD", "MSSQL_FEDERATED_TOKEN_FILE", "MSSQL_ACCESS_TOKEN"} {
+		t.Setenv(env, "")
+	}
+
+	for _, tc := range []struct {
+		name     string
+		query    string
+		env      map[string]string
+		auth     string
+		expected map[string]interface{}
+		err      string
+	}{
+		{
+			name:     "login",
+			query:    "auth=login&username=sa&password=valueIsH8kd$¡",
+			auth:     "login",
+			expected: map[string]interface{}{"username": "sa", "password": "valueIsH8kd$¡"},
+		},
+		{
+			name:     "login from environment",
+			query:    "auth=login",
+			env:      map[string]string{"MSSQL_USERNAME": "sa", "MSSQL_PASSWORD": "valueIsH8kd$¡"},
+			auth:     "login",
+			expected: map[string]interface{}{"username": "sa", "password": "valueIsH8kd$¡"},
+		},
+		{
+			name:  "login without password",
+			query: "auth=login&username=sa",
+			err:   "login requires a username and password",
+		},
+		{
+			name:  "azure_login",
+			query: "auth=azure_login&tenant_id=tenant&client_id=client",
+			env:   map[string]string{"MSSQL_CLIENT_SECRET": "secret"},
+			auth:  "azure_login",
+			expected: map[string]interface{}{"tenant_id": "tenant", "client_id": "client", "client_secret": "secret",
+				"client_certificate_path": "", "client_certificate_password": ""},
+		},
+		{
+			name:  "azure_login certificate with secret in environment",
+			query: "auth=azure_login&tenant_id=tenant&client_id=client&client_certificate_path=/etc/ssl/client.pem",
+			env:   map[string]string{"MSSQL_CLIENT_SECRET": "secret"},
+			auth:  "azure_login",
+			expected: map[string]interface{}{"tenant_id": "tenant", "client_id": "client", "client_secret": "",
+				"client_certificate_path": "/etc/ssl/client.pem", "client_certificate_password": ""},
+		},
+		{
+			name:  "azure_login without credential",
+			query: "auth=azure_login&tenant_id=tenant&client_id=client",
+			err:   "azure_login requires a tenant ID, client ID and client secret or certificate",
+		},
+		{
+			name:     "azuread_default_chain_auth",
+			query:    "auth=azuread_default_chain_auth",
+			auth:     "azuread_default_chain_auth",
+			expected: map[string]interface{}{},
+		},
+		{
+			name:     "azuread_managed_identity_auth",
+			query:    "auth=azuread_managed_identity_auth&user_id=identity",
+			auth:     "azuread_managed_identity_auth",
+			expected: map[string]interface{}{"user_id": "identity"},
+		},
+		{
+			name:     "azuread_workload_identity_auth",
+			query:    "auth=azuread_workload_identity_auth&tenant_id=tenant&client_id=client",
+			env:      map[string]string{"MSSQL_FEDERATED_TOKEN_FILE": "/var/run/token"},
+			auth:     "azuread_workload_identity_auth",
+			expected: map[string]interface{}{"tenant_id": "tenant", "client_id": "client", "token_file_path": "/var/run/token"},
+		},
+		{
+			name:     "azuread_access_token_auth",
+			query:    "auth=azuread_access_token_auth",
+			env:      map[string]string{"MSSQL_ACCESS_TOKEN": "eyJ0eXAi"},
+			auth:     "azuread_access_token_auth",
+			expected: map[string]interface{}{"access_token": "eyJ0eXAi"},
+		},
+		{
+			name:  "azuread_access_token_auth without token",
+			query: "auth=azuread_access_token_auth&access_token=eyJ0eXAi",
+			err:   "azuread_access_token_auth requires the MSSQL_ACCESS_TOKEN environment variable",
+		},
+		{
+			name:  "missing",
+			query: "",
+			err:   "neither login nor azure login specified",
+		},
+		{
+			name:  "unknown",
+			query: "auth=kerberos",
+			err:   "unknown auth [kerberos] in ID",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			server, u, err := serverFromId("sqlserver://localhost:1433/login/test?" + tc.query)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Errorf("expected an error containing %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.Path != "/login/test" {
+				t.Errorf("expected path /login/test, got %s", u.Path)
+			}
+			block, ok := server[0][tc.auth].([]map[string]interface{})
+			if !ok || len(block) != 1 {
+				t.Fatalf("expected a %s block, got %v", tc.auth, server[0])
+			}
+			if !reflect.DeepEqual(block[0], tc.expected) {
+				t.Errorf("expected %s %v, got %v", tc.auth, tc.expected, block[0])
+			}
+		})
+	}
+}
+
+func TestSetServerFromId_DefaultServer(t *testing.T) {
+	t.Setenv("MSSQL_USERNAME", "sa")
+	t.Setenv("MSSQL_PASSWORD", "valueIsH8kd$¡")
+
+	for _, tc := range []struct {
+		name     string
+		id       string
+		provider fakeProvider
+		host     string
+	}{
+		{"default server", "sqlserver://localhost:1433/master/schema/test", fakeProvider{}, ""},
+		{"default server and port", "sqlserver://LocalHost/master/schema/test", fakeProvider{}, ""},
+		{"other host", "sqlserver://other:1433/master/schema/test", fakeProvider{}, "other"},
+		{"other port", "sqlserver://localhost:1434/master/schema/test", fakeProvider{}, "localhost"},
+		{"instance", "sqlserver://localhost:1433/master/schema/test?instance=SQL1", fakeProvider{}, "localhost"},
+		{"credentials", "sqlserver://localhost:1433/master/schema/test?azure=false", fakeProvider{}, "localhost"},
+		{"no default server", "sqlserver://localhost:1433/master/schema/test", fakeProvider{noDefaultServer: true}, "localhost"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data := resourceDatabaseSchema().TestResourceData()
+			data.SetId(tc.id)
+
+			if _, err := setServerFromId(tc.provider, data); err != nil {
+				t.Fatal(err)
+			}
+			host, _ := data.Get("server.0.host").(string)
+			if host != tc.host {
+				t.Errorf("expected server host %q, got %q", tc.host, host)
+			}
+			if ref := data.Get(serverRefProp).(string); ref != "" {
+				t.Errorf("expected no server_ref, got %q", ref)
+			}
+		})
+	}
+}
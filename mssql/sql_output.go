@@ -0,0 +1,91 @@
+package mssql
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+const sqlOutputHeader = `-- T-SQL that terraform-provider-mssql would have executed. None of it was run.
+-- Sensitive values are SQLCMD variables, e.g. run with: sqlcmd -i <file> -v mssql_login_app_password="..."
+
+`
+
+var (
+	sqlOutputsMu sync.Mutex
+	sqlOutputs   = make(map[string]*os.File)
+)
+
+// openSQLOutput returns the file at path the statements of the run are
+// written to. It is started anew by the first provider configuration of the
+// run and shared by the others.
+func openSQLOutput(path string) (io.Writer, error) {
+	sqlOutputsMu.Lock()
+	defer sqlOutputsMu.Unlock()
+
+	if f, ok := sqlOutputs[path]; ok {
+		return f, nil
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening SQL output file %s", path)
+	}
+	if _, err := f.WriteString(sqlOutputHeader); err != nil {
+		f.Close()
+		return nil, errors.Wrapf(err, "error writing SQL output file %s", path)
+	}
+	sqlOutputs[path] = f
+	return f, nil
+}
+
+// sqlOutputResource wraps the create, update and delete functions of r so
+// that they fail once their statements were written to the SQL output, and
+// Terraform does not record changes that were not made. It expects the
+// operation in the context, see traceResource.
+func sqlOutputResource(r *schema.Resource) {
+	if r.CreateContext != nil {
+		r.CreateContext = sqlOutputCRUD(r, r.CreateContext)
+	}
+	if r.UpdateContext != nil {
+		r.UpdateContext = sqlOutputCRUD(r, r.UpdateContext)
+	}
+	if r.DeleteContext != nil {
+		r.DeleteContext = sqlOutputCRUD(r, r.DeleteContext)
+	}
+}
+
+func sqlOutputCRUD(r *schema.Resource, fn crudFunc) crudFunc {
+	return func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		op, _ := model.OperationFromContext(ctx)
+		op.Emitted = &atomic.Bool{}
+		id := data.Id()
+
+		diags := fn(model.WithOperation(ctx, op), data, meta)
+		if !op.Emitted.Load() {
+			return diags
+		}
+		// Keep the state as it was: no resource is created, an updated one
+		// keeps its prior values and a deleted one is kept.
+		data.SetId(id)
+		for prop := range r.Schema {
+			if data.HasChange(prop) {
+				oldValue, _ := data.GetChange(prop)
+				if err := data.Set(prop, oldValue); err != nil {
+					diags = append(diags, diag.FromErr(err)...)
+				}
+			}
+		}
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Statements written to sql_output_path",
+			Detail:   "The statements of this change were written to the SQL output for review and were not executed, so Terraform does not record the change.",
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package mssql
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// tracer records spans with the tracer provider installed by
+// configureTracing; until then, and when tracing is disabled, it does nothing.
+var tracer = otel.Tracer("github.com/Jake-Barrow/terraform-provider-mssql/mssql")
+
+var (
+	tracingMu      sync.Mutex
+	tracerProvider *sdktrace.TracerProvider
+)
+
+// configureTracing installs the global tracer provider exporting to
+// exporter, one of otlp, file or stdout. The first provider configuration
+// that enables tracing wins, since spans of all of them share the process.
+func configureTracing(ctx context.Context, exporter, endpoint, path string) error {
+	if exporter == "" || exporter == "none" {
+		return nil
+	}
+
+	tracingMu.Lock()
+	defer tracingMu.Unlock()
+
+	if tracerProvider != nil {
+		return nil
+	}
+
+	var spanExporter sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case "otlp":
+		// Without an endpoint, the exporter reads the standard
+		// OTEL_EXPORTER_OTLP_* environment variables.
+		var opts []otlptracehttp.Option
+		if endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(endpoint))
+		}
+		spanExporter, err = otlptracehttp.New(ctx, opts...)
+	case "file":
+		var file *os.File
+		if file, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644); err == nil {
+			spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(file))
+		}
+	case "stdout":
+		spanExporter, err = stdouttrace.New()
+	default:
+		err = errors.Errorf("unknown tracing exporter [%s]", exporter)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "error creating %s tracing exporter", exporter)
+	}
+
+	tracerProvider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(sdkresource.NewSchemaless(
+			attribute.String("service.name", "terraform-provider-mssql"),
+		)),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	return nil
+}
+
+// ShutdownTracing exports the spans that are still buffered. It is called
+// when the plugin exits.
+func ShutdownTracing(ctx context.Context) error {
+	tracingMu.Lock()
+	defer tracingMu.Unlock()
+
+	if tracerProvider == nil {
+		return nil
+	}
+	return tracerProvider.Shutdown(ctx)
+}
+
+// flushTracing exports the spans that are buffered so far.
+func flushTracing(ctx context.Context) error {
+	tracingMu.Lock()
+	defer tracingMu.Unlock()
+
+	if tracerProvider == nil {
+		return nil
+	}
+	return tracerProvider.ForceFlush(ctx)
+}
+
+type crudFunc = func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+
+// traceResource wraps the CRUD and import functions of r in spans named
+// after the resource type, e.g. mssql_login.create. The operation is also
+// added to the context, e.g. to annotate the statements of sql_output_path.
+func traceResource(name string, r *schema.Resource) {
+	if r.CreateContext != nil {
+		r.CreateContext = traceCRUD(name, "create", r.CreateContext)
+	}
+	if r.ReadContext != nil {
+		r.ReadContext = traceCRUD(name, "read", r.ReadContext)
+	}
+	if r.UpdateContext != nil {
+		r.UpdateContext = traceCRUD(name, "update", r.UpdateContext)
+	}
+	if r.DeleteContext != nil {
+		r.DeleteContext = traceCRUD(name, "delete", r.DeleteContext)
+	}
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		importer := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, data *schema.ResourceData, meta interface{}) (result []*schema.ResourceData, err error) {
+			ctx = model.WithOperation(ctx, model.Operation{Resource: name, Action: "import", ID: data.Id()})
+			ctx, span := tracer.Start(ctx, name+".import")
+			defer func() {
+				if err != nil {
+					span.RecordError(err)
+					span.SetStatus(codes.Error, err.Error())
+				}
+				span.End()
+			}()
+			return importer(ctx, data, meta)
+		}
+	}
+}
+
+func traceCRUD(resource, action string, fn crudFunc) crudFunc {
+	return func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		ctx = model.WithOperation(ctx, model.Operation{Resource: resource, Action: action, ID: data.Id()})
+		ctx, span := tracer.Start(ctx, resource+"."+action)
+		defer span.End()
+
+		diags := fn(ctx, data, meta)
+		if diags.HasError() {
+			for _, d := range diags {
+				if d.Severity == diag.Error {
+					span.SetStatus(codes.Error, d.Summary)
+					break
+				}
+			}
+		}
+		return diags
+	}
+}
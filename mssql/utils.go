@@ -1,88 +1,179 @@
 package mssql
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/rs/zerolog"
 )
 
-func getLoginID(data *schema.ResourceData) string {
-	host := data.Get(serverProp + ".0.host").(string)
-	port := data.Get(serverProp + ".0.port").(string)
+func getLoginID(meta interface{}, data *schema.ResourceData) (string, error) {
+	host, port, instance, err := getServerAddress(meta, data)
+	if err != nil {
+		return "", err
+	}
 	loginName := data.Get(loginNameProp).(string)
-	return fmt.Sprintf("sqlserver://%s:%s/login/%s", host, port, loginName)
+	return withInstance(fmt.Sprintf("sqlserver://%s:%s/login/%s", host, port, loginName), instance), nil
 }
 
-func getUserID(data *schema.ResourceData) string {
-	host := data.Get(serverProp + ".0.host").(string)
-	port := data.Get(serverProp + ".0.port").(string)
+func getUserID(meta interface{}, data *schema.ResourceData) (string, error) {
+	host, port, instance, err := getServerAddress(meta, data)
+	if err != nil {
+		return "", err
+	}
 	database := data.Get(databaseProp).(string)
 	username := data.Get(usernameProp).(string)
-	return fmt.Sprintf("sqlserver://%s:%s/%s/user/%s", host, port, database, username)
+	return withInstance(fmt.Sprintf("sqlserver://%s:%s/%s/user/%s", host, port, database, username), instance), nil
 }
 
-func getDatabasePermissionsID(data *schema.ResourceData) string {
-	host := data.Get(serverProp + ".0.host").(string)
-	port := data.Get(serverProp + ".0.port").(string)
+func getDatabasePermissionsID(meta interface{}, data *schema.ResourceData) (string, error) {
+	host, port, instance, err := getServerAddress(meta, data)
+	if err != nil {
+		return "", err
+	}
 	database := data.Get(databaseProp).(string)
 	username := data.Get(usernameProp).(string)
-	return fmt.Sprintf("sqlserver://%s:%s/%s/permission/%s", host, port, database, username)
+	return withInstance(fmt.Sprintf("sqlserver://%s:%s/%s/permission/%s", host, port, database, username), instance), nil
 }
 
-func getDatabaseRoleID(data *schema.ResourceData) string {
-	host := data.Get(serverProp + ".0.host").(string)
-	port := data.Get(serverProp + ".0.port").(string)
+func getDatabaseRoleID(meta interface{}, data *schema.ResourceData) (string, error) {
+	host, port, instance, err := getServerAddress(meta, data)
+	if err != nil {
+		return "", err
+	}
 	database := data.Get(databaseProp).(string)
 	roleName := data.Get(roleNameProp).(string)
-	return fmt.Sprintf("sqlserver://%s:%s/%s/role/%s", host, port, database, roleName)
+	return withInstance(fmt.Sprintf("sqlserver://%s:%s/%s/role/%s", host, port, database, roleName), instance), nil
+}
+
+func getDatabaseRoleMemberID(meta interface{}, data *schema.ResourceData) (string, error) {
+	host, port, instance, err := getServerAddress(meta, data)
+	if err != nil {
+		return "", err
+	}
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+	memberName := data.Get(memberNameProp).(string)
+	return withInstance(fmt.Sprintf("sqlserver://%s:%s/%s/role/%s/member/%s", host, port, database, roleName, memberName), instance), nil
+}
+
+func getApplicationRoleID(meta interface{}, data *schema.ResourceData) (string, error) {
+	host, port, instance, err := getServerAddress(meta, data)
+	if err != nil {
+		return "", err
+	}
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+	return withInstance(fmt.Sprintf("sqlserver://%s:%s/%s/application_role/%s", host, port, database, roleName), instance), nil
+}
+
+func getServerRoleID(meta interface{}, data *schema.ResourceData) (string, error) {
+	host, port, instance, err := getServerAddress(meta, data)
+	if err != nil {
+		return "", err
+	}
+	roleName := data.Get(roleNameProp).(string)
+	return withInstance(fmt.Sprintf("sqlserver://%s:%s/server_role/%s", host, port, roleName), instance), nil
+}
+
+func getServerRoleMemberID(meta interface{}, data *schema.ResourceData) (string, error) {
+	host, port, instance, err := getServerAddress(meta, data)
+	if err != nil {
+		return "", err
+	}
+	roleName := data.Get(roleNameProp).(string)
+	memberName := data.Get(memberNameProp).(string)
+	return withInstance(fmt.Sprintf("sqlserver://%s:%s/server_role/%s/member/%s", host, port, roleName, memberName), instance), nil
+}
+
+func getServerPermissionsID(meta interface{}, data *schema.ResourceData) (string, error) {
+	host, port, instance, err := getServerAddress(meta, data)
+	if err != nil {
+		return "", err
+	}
+	principalName := data.Get(principalNameProp).(string)
+	return withInstance(fmt.Sprintf("sqlserver://%s:%s/server_permission/%s", host, port, principalName), instance), nil
 }
 
-func getDatabaseSchemaID(data *schema.ResourceData) string {
-	host := data.Get(serverProp + ".0.host").(string)
-	port := data.Get(serverProp + ".0.port").(string)
+func getDatabaseSchemaID(meta interface{}, data *schema.ResourceData) (string, error) {
+	host, port, instance, err := getServerAddress(meta, data)
+	if err != nil {
+		return "", err
+	}
 	database := data.Get(databaseProp).(string)
 	schemaName := data.Get(schemaNameProp).(string)
-	return fmt.Sprintf("sqlserver://%s:%s/%s/schema/%s", host, port, database, schemaName)
+	return withInstance(fmt.Sprintf("sqlserver://%s:%s/%s/schema/%s", host, port, database, schemaName), instance), nil
 }
 
-func getDatabaseCredentialID(data *schema.ResourceData) string {
-	host := data.Get(serverProp + ".0.host").(string)
-	port := data.Get(serverProp + ".0.port").(string)
+func getDatabaseCredentialID(meta interface{}, data *schema.ResourceData) (string, error) {
+	host, port, instance, err := getServerAddress(meta, data)
+	if err != nil {
+		return "", err
+	}
 	database := data.Get(databaseProp).(string)
 	credentialname := data.Get(credentialNameProp).(string)
-	return fmt.Sprintf("sqlserver://%s:%s/%s/credential/%s", host, port, database, credentialname)
+	return withInstance(fmt.Sprintf("sqlserver://%s:%s/%s/credential/%s", host, port, database, credentialname), instance), nil
 }
 
-func getDatabaseMasterkeyID(data *schema.ResourceData) string {
-	host := data.Get(serverProp + ".0.host").(string)
-	port := data.Get(serverProp + ".0.port").(string)
+func getDatabaseMasterkeyID(meta interface{}, data *schema.ResourceData) (string, error) {
+	host, port, instance, err := getServerAddress(meta, data)
+	if err != nil {
+		return "", err
+	}
 	database := data.Get(databaseProp).(string)
-	return fmt.Sprintf("sqlserver://%s:%s/%s/masterkey", host, port, database)
+	return withInstance(fmt.Sprintf("sqlserver://%s:%s/%s/masterkey", host, port, database), instance), nil
 }
 
-func getAzureExternalDatasourceID(data *schema.ResourceData) string {
-	host := data.Get(serverProp + ".0.host").(string)
-	port := data.Get(serverProp + ".0.port").(string)
+func getAzureExternalDatasourceID(meta interface{}, data *schema.ResourceData) (string, error) {
+	host, port, instance, err := getServerAddress(meta, data)
+	if err != nil {
+		return "", err
+	}
 	database := data.Get(databaseProp).(string)
 	datasourcename := data.Get(datasourcenameProp).(string)
-	return fmt.Sprintf("sqlserver://%s:%s/%s/externaldatasource/%s", host, port, database, datasourcename)
+	return withInstance(fmt.Sprintf("sqlserver://%s:%s/%s/externaldatasource/%s", host, port, database, datasourcename), instance), nil
 }
 
-func getDatabaseSQLScriptID(data *schema.ResourceData) string {
-	host := data.Get(serverProp + ".0.host").(string)
-	port := data.Get(serverProp + ".0.port").(string)
+func getDatabaseSQLScriptID(meta interface{}, data *schema.ResourceData) (string, error) {
+	host, port, instance, err := getServerAddress(meta, data)
+	if err != nil {
+		return "", err
+	}
 	database := data.Get(databaseProp).(string)
 	verifyObject := data.Get(verifyObjectProp).(string)
 	id := fmt.Sprintf("%s:%s", database, verifyObject)
 	encodedID := base64.URLEncoding.EncodeToString([]byte(id))
-	return fmt.Sprintf("sqlserver://%s:%s/%s/sqlscript/%s", host, port, database, encodedID)
+	return withInstance(fmt.Sprintf("sqlserver://%s:%s/%s/sqlscript/%s", host, port, database, encodedID), instance), nil
 }
 
-func loggerFromMeta(meta interface{}, resource, function string) zerolog.Logger {
-	return meta.(model.Provider).ResourceLogger(resource, function)
+// getServerAddress returns the host, port and instance of the server a
+// resource is managed on, whether it comes from an inline block or a provider
+// profile.
+func getServerAddress(meta interface{}, data *schema.ResourceData) (string, string, string, error) {
+	server, err := meta.(model.Provider).GetServer(serverProp, data)
+	if err != nil {
+		return "", "", "", err
+	}
+	instance, _ := server["instance"].(string)
+	return server["host"].(string), server["port"].(string), instance, nil
+}
+
+// withInstance adds the named instance, if any, to a resource ID, so that
+// importing the ID connects to the same instance.
+func withInstance(id, instance string) string {
+	if instance == "" {
+		return id
+	}
+	return id + "?instance=" + url.QueryEscape(instance)
+}
+
+func loggerFromMeta(ctx context.Context, meta interface{}, resource, function string) zerolog.Logger {
+	return meta.(model.Provider).ResourceLogger(ctx, resource, function)
 }
 
 func toStringSlice(values []interface{}) []string {
@@ -93,6 +184,52 @@ func toStringSlice(values []interface{}) []string {
 	return result
 }
 
+// matchSetCase returns names, each spelled as the element of the set prop
+// that is equal to it ignoring case, so names read from the server in another
+// case than the configuration do not show up as a diff.
+func matchSetCase(data *schema.ResourceData, prop string, names []string) []string {
+	spelling := make(map[string]string)
+	for _, v := range data.Get(prop).(*schema.Set).List() {
+		spelling[strings.ToLower(v.(string))] = v.(string)
+	}
+	result := make([]string, len(names))
+	for i, name := range names {
+		if s, ok := spelling[strings.ToLower(name)]; ok {
+			name = s
+		}
+		result[i] = name
+	}
+	return result
+}
+
+// customizeDiffEmptySet plans the removal of all elements of the Optional and
+// Computed set prop when it is set to an empty set, which the SDK otherwise
+// takes for an unset attribute and leaves to its current value.
+func customizeDiffEmptySet(data *schema.ResourceDiff, prop string) error {
+	config := data.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() {
+		return nil
+	}
+	value := config.GetAttr(prop)
+	if value.IsNull() || !value.IsKnown() || value.LengthInt() > 0 {
+		return nil
+	}
+	if data.Get(prop).(*schema.Set).Len() == 0 {
+		return nil
+	}
+	return data.SetNew(prop, []string{})
+}
+
+// isNullInConfig reports whether prop is left out of the configuration, as
+// opposed to set to an empty value, which the SDK does not tell apart.
+func isNullInConfig(data *schema.ResourceData, prop string) bool {
+	config := data.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() {
+		return false
+	}
+	return config.GetAttr(prop).IsNull()
+}
+
 func equal(a, b interface{}) bool {
 	switch a.(type) {
 	case []string:
@@ -3,6 +3,7 @@ package validate
 import (
 	"fmt"
 	"regexp"
+	"time"
 )
 
 func SQLIdentifier(i interface{}, k string) (warnings []string, errors []error) {
@@ -54,3 +55,19 @@ func SQLIdentifierPassword(i interface{}, k string) (warnings []string, errors [
 		return
 	}
 }
+
+func Duration(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if d, err := time.ParseDuration(v); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a duration such as 500ms or 2s: %v", k, err))
+	} else if d < 0 {
+		errors = append(errors, fmt.Errorf("%q cannot be negative: %q", k, v))
+	}
+
+	return
+}
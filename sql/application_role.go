@@ -0,0 +1,96 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+)
+
+func (c *Connector) GetApplicationRole(ctx context.Context, database, roleName string) (*model.ApplicationRole, error) {
+	cmd := `SELECT principal_id, name, COALESCE(default_schema_name, '')
+			FROM [sys].[database_principals]
+			WHERE type = 'A'
+				AND name = @roleName`
+	var role model.ApplicationRole
+	err := c.
+		setDatabase(&database).
+		QueryRowContext(ctx, cmd,
+			func(r *sql.Row) error {
+				return r.Scan(&role.RoleID, &role.RoleName, &role.DefaultSchema)
+			},
+			sql.Named("roleName", roleName),
+		)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (c *Connector) CreateApplicationRole(ctx context.Context, database, roleName, password, defaultSchema string) error {
+	cmd := `DECLARE @sql nvarchar(max)
+			SET @sql = 'CREATE APPLICATION ROLE ' + QuoteName(@roleName) + ' WITH PASSWORD = ' + QuoteName(@password, '''')
+			IF @defaultSchema != ''
+				BEGIN
+					SET @sql = @sql + ', DEFAULT_SCHEMA = ' + QuoteName(@defaultSchema)
+				END
+			EXEC (@sql)`
+	return c.
+		setDatabase(&database).
+		ExecContext(ctx, cmd,
+			sql.Named("roleName", roleName),
+			sql.Named("password", password),
+			sql.Named("defaultSchema", defaultSchema),
+		)
+}
+
+// UpdateApplicationRole renames the application role with the given principal
+// id to roleName, and sets its password and default schema unless they are
+// empty.
+func (c *Connector) UpdateApplicationRole(ctx context.Context, database string, roleId int, roleName, password, defaultSchema string) error {
+	cmd := `DECLARE @sql nvarchar(max)
+			DECLARE @options nvarchar(max) = ''
+			DECLARE @old_role_name nvarchar(max) = (SELECT name FROM [sys].[database_principals] WHERE [type] = 'A' AND [principal_id] = @principalId)
+			IF @old_role_name != @roleName
+				BEGIN
+					SET @options = 'NAME = ' + QuoteName(@roleName)
+				END
+			IF @password != ''
+				BEGIN
+					SET @options = @options + CASE WHEN @options = '' THEN '' ELSE ', ' END + 'PASSWORD = ' + QuoteName(@password, '''')
+				END
+			IF @defaultSchema != ''
+				BEGIN
+					SET @options = @options + CASE WHEN @options = '' THEN '' ELSE ', ' END + 'DEFAULT_SCHEMA = ' + QuoteName(@defaultSchema)
+				END
+			IF @options != ''
+				BEGIN
+					SET @sql = 'ALTER APPLICATION ROLE ' + QuoteName(@old_role_name) + ' WITH ' + @options
+					EXEC (@sql)
+				END`
+	return c.
+		setDatabase(&database).
+		ExecContext(ctx, cmd,
+			sql.Named("principalId", roleId),
+			sql.Named("roleName", roleName),
+			sql.Named("password", password),
+			sql.Named("defaultSchema", defaultSchema),
+		)
+}
+
+func (c *Connector) DeleteApplicationRole(ctx context.Context, database, roleName string) error {
+	cmd := `DECLARE @sql nvarchar(max)
+			IF EXISTS (SELECT 1 FROM [sys].[database_principals] WHERE [type] = 'A' AND [name] = @roleName)
+				BEGIN
+					SET @sql = 'DROP APPLICATION ROLE ' + QuoteName(@roleName)
+					EXEC (@sql)
+				END`
+	return c.
+		setDatabase(&database).
+		ExecContext(ctx, cmd,
+			sql.Named("roleName", roleName),
+		)
+}
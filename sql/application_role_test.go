@@ -0,0 +1,67 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+)
+
+func TestGetApplicationRole(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	fake.addRows(
+		[]string{"principal_id", "name", "default_schema_name"},
+		[]driver.Value{int64(7), "legacy_app", "sales"},
+	)
+
+	role, err := connector.GetApplicationRole(context.Background(), "db", "legacy_app")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &model.ApplicationRole{RoleID: 7, RoleName: "legacy_app", DefaultSchema: "sales"}
+	if !reflect.DeepEqual(role, expected) {
+		t.Errorf("expected %+v, got %+v", expected, role)
+	}
+	fake.expectStatements(t, fakeStatement{
+		query: "WHERE type = 'A'",
+		args:  map[string]interface{}{"roleName": "legacy_app"},
+	})
+}
+
+func TestCreateApplicationRole(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+
+	if err := connector.CreateApplicationRole(context.Background(), "db", "legacy_app", "valueIsH8kd$¡", "sales"); err != nil {
+		t.Fatal(err)
+	}
+
+	fake.expectStatements(t, fakeStatement{
+		query: "'CREATE APPLICATION ROLE ' + QuoteName(@roleName) + ' WITH PASSWORD = ' + QuoteName(@password, '''')",
+		args: map[string]interface{}{
+			"roleName":      "legacy_app",
+			"password":      "valueIsH8kd$¡",
+			"defaultSchema": "sales",
+		},
+	})
+}
+
+func TestUpdateApplicationRole(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+
+	if err := connector.UpdateApplicationRole(context.Background(), "db", 7, "legacy_app", "rotatedH8kd$¡", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	fake.expectStatements(t, fakeStatement{
+		query: "'ALTER APPLICATION ROLE ' + QuoteName(@old_role_name) + ' WITH ' + @options",
+		args: map[string]interface{}{
+			"principalId":   7,
+			"roleName":      "legacy_app",
+			"password":      "rotatedH8kd$¡",
+			"defaultSchema": "",
+		},
+	})
+}
@@ -0,0 +1,77 @@
+package sql
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+)
+
+// auditStatement adds the audit record of a statement executed for a create,
+// update or delete to the audit log of the operation. The record is written
+// to the audit table of the database the statement ran in once the operation
+// succeeded, when the resource ID is known.
+func (c *Connector) auditStatement(ctx context.Context, statement string, args []interface{}) {
+	op, ok := model.OperationFromContext(ctx)
+	if !ok || op.Audit == nil {
+		return
+	}
+	hash, table := statementHash(statement, args), c.auditTable
+	// The record itself is not audited.
+	connector := *c
+	connector.auditTable = ""
+	op.Audit.Add(func(ctx context.Context, id string) error {
+		return connector.insertAuditRecord(ctx, table, op.Resource, id, op.Action, hash)
+	})
+}
+
+// statementHash is the SHA-256 of a statement and its parameters, with the
+// values of sensitive parameters masked so they can't be brute-forced.
+func statementHash(statement string, args []interface{}) string {
+	statement, params := maskStatement(statement, args)
+	// Maps are encoded with sorted keys, so the hash is stable.
+	encoded, _ := json.Marshal(params)
+	sum := sha256.Sum256(append([]byte(statement), encoded...))
+	return hex.EncodeToString(sum[:])
+}
+
+// insertAuditRecord creates the audit table, [schema.]table, if it is missing,
+// and inserts one record into it.
+func (c *Connector) insertAuditRecord(ctx context.Context, table, resourceType, resourceID, operation, hash string) error {
+	schemaName, tableName, ok := strings.Cut(table, ".")
+	if !ok {
+		schemaName, tableName = "dbo", table
+	}
+	cmd := `DECLARE @table nvarchar(max) = QuoteName(@schemaName) + '.' + QuoteName(@tableName)
+			DECLARE @sql nvarchar(max)
+			IF OBJECT_ID(@table, 'U') IS NULL
+				BEGIN
+					SET @sql = 'CREATE TABLE ' + @table + ' (' +
+								'id bigint IDENTITY(1,1) PRIMARY KEY, ' +
+								'resource_type nvarchar(128) NOT NULL, ' +
+								'resource_id nvarchar(max) NOT NULL, ' +
+								'operation nvarchar(16) NOT NULL, ' +
+								'principal nvarchar(128) NOT NULL, ' +
+								'changed_at datetime2 NOT NULL, ' +
+								'statement_hash char(64) NOT NULL' +
+								')'
+					EXEC (@sql)
+				END
+			SET @sql = 'INSERT INTO ' + @table + ' (resource_type, resource_id, operation, principal, changed_at, statement_hash) ' +
+						'VALUES (@resourceType, @resourceId, @operation, ORIGINAL_LOGIN(), SYSUTCDATETIME(), @statementHash)'
+			EXEC sp_executesql @sql,
+				N'@resourceType nvarchar(128), @resourceId nvarchar(max), @operation nvarchar(16), @statementHash char(64)',
+				@resourceType, @resourceId, @operation, @statementHash`
+	return c.ExecContext(ctx, cmd,
+		sql.Named("schemaName", schemaName),
+		sql.Named("tableName", tableName),
+		sql.Named("resourceType", resourceType),
+		sql.Named("resourceId", resourceID),
+		sql.Named("operation", operation),
+		sql.Named("statementHash", hash),
+	)
+}
@@ -0,0 +1,104 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+)
+
+func TestExecContext_Audit(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	connector.auditTable = "terraform_change_log"
+
+	audit := &model.AuditLog{}
+	ctx := model.WithOperation(context.Background(), model.Operation{Resource: "mssql_login", Action: "create", Audit: audit})
+	if err := connector.CreateLogin(ctx, "login", "valueIsH8kd$¡", "", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := audit.Write(ctx, "sqlserver://localhost:1433/login"); err != nil {
+		t.Fatal(err)
+	}
+
+	fake.mu.Lock()
+	hash, _ := fake.statements[len(fake.statements)-1].args["statementHash"].(string)
+	fake.mu.Unlock()
+	if len(hash) != 64 {
+		t.Fatalf("expected a SHA-256 statement hash, got %q", hash)
+	}
+	fake.expectStatements(t,
+		fakeStatement{
+			query: "SET @sql = 'CREATE LOGIN ' + QuoteName(@name)",
+			args: map[string]interface{}{
+				"name":            "login",
+				"password":        "valueIsH8kd$¡",
+				"sid":             "",
+				"defaultDatabase": "",
+				"defaultLanguage": "",
+				"azureDatabase":   false,
+			},
+		},
+		fakeStatement{
+			query: "'INSERT INTO ' + @table",
+			args: map[string]interface{}{
+				"schemaName":    "dbo",
+				"tableName":     "terraform_change_log",
+				"resourceType":  "mssql_login",
+				"resourceId":    "sqlserver://localhost:1433/login",
+				"operation":     "create",
+				"statementHash": hash,
+			},
+		},
+	)
+}
+
+func TestStatementHash(t *testing.T) {
+	args := []interface{}{sql.Named("name", "login"), sql.Named("password", "valueIsH8kd$¡")}
+	hash := statementHash("CREATE LOGIN", args)
+	if hash != statementHash("CREATE LOGIN", []interface{}{sql.Named("name", "login"), sql.Named("password", "other")}) {
+		t.Error("expected the hash not to depend on the password")
+	}
+	if hash == statementHash("CREATE LOGIN", []interface{}{sql.Named("name", "other"), sql.Named("password", "valueIsH8kd$¡")}) {
+		t.Error("expected the hash to depend on the parameters")
+	}
+}
+
+func TestDataBaseExecuteScript_AuditSelect(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	connector.auditTable = "terraform_change_log"
+
+	// Scripts starting with SELECT may still write, so they are audited too.
+	audit := &model.AuditLog{}
+	ctx := model.WithOperation(context.Background(), model.Operation{Resource: "mssql_database_sqlscript", Action: "create", Audit: audit})
+	if err := connector.DataBaseExecuteScript(ctx, "db", "SELECT * INTO t2 FROM t"); err != nil {
+		t.Fatal(err)
+	}
+	if err := audit.Write(ctx, "sqlserver://localhost:1433/db/sqlscript"); err != nil {
+		t.Fatal(err)
+	}
+
+	fake.mu.Lock()
+	hash, _ := fake.statements[len(fake.statements)-1].args["statementHash"].(string)
+	fake.mu.Unlock()
+	fake.expectStatements(t,
+		fakeStatement{
+			query: "EXEC sp_executesql @stmt",
+			args: map[string]interface{}{
+				"script":   "SELECT * INTO t2 FROM t",
+				"database": "db",
+			},
+		},
+		fakeStatement{
+			query: "'INSERT INTO ' + @table",
+			args: map[string]interface{}{
+				"schemaName":    "dbo",
+				"tableName":     "terraform_change_log",
+				"resourceType":  "mssql_database_sqlscript",
+				"resourceId":    "sqlserver://localhost:1433/db/sqlscript",
+				"operation":     "create",
+				"statementHash": hash,
+			},
+		},
+	)
+}
@@ -0,0 +1,85 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+)
+
+// capabilities is shared by all connectors of the process, so each server is
+// detected once per run instead of once per statement.
+var capabilities = &capabilityCache{entries: make(map[capabilityKey]*capabilityEntry)}
+
+type capabilityKey struct {
+	host     string
+	port     string
+	instance string
+}
+
+type capabilityEntry struct {
+	mu           sync.Mutex
+	capabilities *model.ServerCapabilities
+}
+
+type capabilityCache struct {
+	mu      sync.Mutex
+	entries map[capabilityKey]*capabilityEntry
+}
+
+func (t *capabilityCache) entry(key capabilityKey) *capabilityEntry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	e, ok := t.entries[key]
+	if !ok {
+		e = &capabilityEntry{}
+		t.entries[key] = e
+	}
+	return e
+}
+
+// GetServerCapabilities returns the engine edition, version and HADR state
+// of the server, detected on the first call for the server and cached for the
+// rest of the run.
+func (c *Connector) GetServerCapabilities(ctx context.Context) (*model.ServerCapabilities, error) {
+	e := capabilities.entry(capabilityKey{host: c.Host, port: c.Port, instance: c.Instance})
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.capabilities != nil {
+		return e.capabilities, nil
+	}
+
+	var caps model.ServerCapabilities
+	err := c.QueryRowContext(ctx,
+		`SELECT CAST(SERVERPROPERTY('EngineEdition') AS int),
+				CAST(SERVERPROPERTY('ProductVersion') AS nvarchar(128)),
+				CAST(SERVERPROPERTY('Edition') AS nvarchar(128)),
+				COALESCE(CAST(SERVERPROPERTY('IsHadrEnabled') AS bit), 0)`,
+		func(r *sql.Row) error {
+			return r.Scan(&caps.EngineEdition, &caps.ProductVersion, &caps.Edition, &caps.HadrEnabled)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// sys.availability_groups has is_contained since SQL Server 2022.
+	if caps.HadrEnabled && caps.MajorVersion() >= 16 {
+		err = c.QueryRowContext(ctx,
+			"SELECT CAST(COUNT(1) AS bit) FROM [sys].[availability_groups] WHERE is_contained = 1",
+			func(r *sql.Row) error {
+				return r.Scan(&caps.ContainedAG)
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	e.capabilities = &caps
+	return e.capabilities, nil
+}
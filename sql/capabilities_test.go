@@ -0,0 +1,52 @@
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+)
+
+func TestGetServerCapabilities(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	fake.capabilities.HadrEnabled = true
+	fake.capabilities.ContainedAG = true
+
+	for i := 0; i < 2; i++ {
+		capabilities, err := connector.GetServerCapabilities(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *capabilities != fake.capabilities {
+			t.Errorf("expected %+v, got %+v", fake.capabilities, *capabilities)
+		}
+	}
+	if fake.detections != 1 {
+		t.Errorf("expected the server to be detected once, got %d", fake.detections)
+	}
+}
+
+func TestServerCapabilities(t *testing.T) {
+	tests := []struct {
+		edition          int
+		azure            bool
+		azureSQLDatabase bool
+	}{
+		{model.EngineEditionEnterprise, false, false},
+		{model.EngineEditionExpress, false, false},
+		{model.EngineEditionSQLDatabase, true, true},
+		{model.EngineEditionManagedInstance, true, false},
+	}
+	for _, test := range tests {
+		capabilities := model.ServerCapabilities{EngineEdition: test.edition, ProductVersion: "12.0.2000.8"}
+		if capabilities.IsAzure() != test.azure {
+			t.Errorf("edition %d: expected IsAzure %t", test.edition, test.azure)
+		}
+		if capabilities.IsAzureSQLDatabase() != test.azureSQLDatabase {
+			t.Errorf("edition %d: expected IsAzureSQLDatabase %t", test.edition, test.azureSQLDatabase)
+		}
+		if capabilities.MajorVersion() != 12 {
+			t.Errorf("expected major version 12, got %d", capabilities.MajorVersion())
+		}
+	}
+}
@@ -9,6 +9,17 @@ import (
 )
 
 func (c *Connector) GetDatabasePermissions(ctx context.Context, database string, username string) (*model.DatabasePermissions, error) {
+	snapshot, err := c.snapshot(ctx, database)
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := snapshot.principal(username); ok {
+		return &model.DatabasePermissions{
+			UserName:     username,
+			DatabaseName: database,
+			Permissions:  append(make([]string, 0, len(p.permissions)), p.permissions...),
+		}, nil
+	}
 	cmd := `DECLARE @stmt nvarchar(max)
 			SET @stmt = 'SELECT DISTINCT pr.principal_id, pr.name, ' +
 						'pe.permission_name ' +
@@ -26,7 +37,7 @@ func (c *Connector) GetDatabasePermissions(ctx context.Context, database string,
 		Permissions:  make([]string, 0),
 	}
 
-	err := c.
+	err = c.
 		setDatabase(&database).
 		QueryContext(ctx, cmd,
 			func(r *sql.Rows) error {
@@ -0,0 +1,83 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+)
+
+func TestCreateDatabasePermissions(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+
+	err := connector.CreateDatabasePermissions(context.Background(), &model.DatabasePermissions{
+		DatabaseName: "db",
+		UserName:     "user",
+		Permissions:  []string{"SELECT", "INSERT"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake.expectStatements(t, fakeStatement{
+		query: "SET @stmt = 'GRANT ' + @permission_name + ' TO ' + QuoteName(@username)",
+		args: map[string]interface{}{
+			"username":    "user",
+			"permissions": "SELECT,INSERT",
+		},
+	})
+}
+
+func TestUpdateDatabasePermissions(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+
+	err := connector.UpdateDatabasePermissions(context.Background(), &model.DatabasePermissions{
+		DatabaseName: "db",
+		UserName:     "user",
+		Permissions:  []string{"EXECUTE"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake.expectStatements(t, fakeStatement{
+		query: "SET @stmt = 'REVOKE ' + @perm_name + ' FROM ' + QuoteName(@username)",
+		args: map[string]interface{}{
+			"username":    "user",
+			"permissions": "EXECUTE",
+		},
+	})
+}
+
+func TestGetDatabasePermissions(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	fake.addRows(
+		[]string{"principal_id", "name", "permission_name"},
+		[]driver.Value{"5", "user", "CONNECT"},
+		[]driver.Value{"5", "user", "SELECT"},
+		[]driver.Value{"5", "user", "EXECUTE"},
+	)
+
+	permissions, err := connector.GetDatabasePermissions(context.Background(), "db", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &model.DatabasePermissions{
+		DatabaseName: "db",
+		UserName:     "user",
+		Permissions:  []string{"SELECT", "EXECUTE"},
+	}
+	if !reflect.DeepEqual(permissions, expected) {
+		t.Errorf("expected %+v, got %+v", expected, permissions)
+	}
+	fake.expectStatements(t, fakeStatement{
+		query: "FROM [sys].[database_principals] AS pr LEFT JOIN [sys].[database_permissions] AS pe",
+		args: map[string]interface{}{
+			"database": "db",
+			"username": "user",
+		},
+	})
+}
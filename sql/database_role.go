@@ -8,12 +8,16 @@ import (
 )
 
 func (c *Connector) GetDatabaseRole(ctx context.Context, database, roleName string) (*model.DatabaseRole, error) {
+	caps, err := c.setDatabase(&database).GetServerCapabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
 	cmd := `SELECT
 				dp2.principal_id,
 				dp2.name,
 				dp2.owning_principal_id,
 				CASE
-					WHEN @@VERSION LIKE 'Microsoft SQL Azure%'
+					WHEN @azure = 1
 						AND @database = 'master'
 						AND (@ownerName = 'dbo' OR @ownerName = '') THEN ''
 					ELSE dp1.name
@@ -24,7 +28,7 @@ func (c *Connector) GetDatabaseRole(ctx context.Context, database, roleName stri
 			WHERE dp2.type = 'R'
 				AND dp2.name = @roleName`
 	var role model.DatabaseRole
-	err := c.
+	err = c.
 		setDatabase(&database).
 		QueryRowContext(ctx, cmd,
 			func(r *sql.Row) error {
@@ -33,6 +37,7 @@ func (c *Connector) GetDatabaseRole(ctx context.Context, database, roleName stri
 			sql.Named("database", database),
 			sql.Named("roleName", roleName),
 			sql.Named("ownerName", role.OwnerName),
+			sql.Named("azure", caps.IsAzure()),
 		)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -40,6 +45,9 @@ func (c *Connector) GetDatabaseRole(ctx context.Context, database, roleName stri
 		}
 		return nil, err
 	}
+	if role.Members, err = c.getDatabaseRoleMembers(ctx, database, role.RoleName); err != nil {
+		return nil, err
+	}
 	return &role, nil
 }
 
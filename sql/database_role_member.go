@@ -0,0 +1,126 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+)
+
+func (c *Connector) GetDatabaseRoleMember(ctx context.Context, database, roleName, memberName string) (*model.DatabaseRoleMember, error) {
+	cmd := `SELECT r.principal_id, r.name, m.principal_id, m.name
+			FROM [sys].[database_role_members] drm
+			INNER JOIN [sys].[database_principals] r ON r.principal_id = drm.role_principal_id
+			INNER JOIN [sys].[database_principals] m ON m.principal_id = drm.member_principal_id
+			WHERE r.name = @roleName
+				AND m.name = @memberName`
+	member := model.DatabaseRoleMember{DatabaseName: database}
+	err := c.
+		setDatabase(&database).
+		QueryRowContext(ctx, cmd,
+			func(r *sql.Row) error {
+				return r.Scan(&member.RoleID, &member.RoleName, &member.MemberID, &member.MemberName)
+			},
+			sql.Named("roleName", roleName),
+			sql.Named("memberName", memberName),
+		)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (c *Connector) AddDatabaseRoleMember(ctx context.Context, database, roleName, memberName string) error {
+	cmd := `DECLARE @sql nvarchar(max)
+			SET @sql = 'ALTER ROLE ' + QuoteName(@roleName) + ' ADD MEMBER ' + QuoteName(@memberName)
+			EXEC (@sql)`
+	return c.
+		setDatabase(&database).
+		ExecContext(ctx, cmd,
+			sql.Named("roleName", roleName),
+			sql.Named("memberName", memberName),
+		)
+}
+
+func (c *Connector) DropDatabaseRoleMember(ctx context.Context, database, roleName, memberName string) error {
+	cmd := `DECLARE @sql nvarchar(max)
+			IF EXISTS (SELECT 1 FROM [sys].[database_role_members]
+						WHERE role_principal_id = DATABASE_PRINCIPAL_ID(@roleName)
+							AND member_principal_id = DATABASE_PRINCIPAL_ID(@memberName))
+				BEGIN
+					SET @sql = 'ALTER ROLE ' + QuoteName(@roleName) + ' DROP MEMBER ' + QuoteName(@memberName)
+					EXEC (@sql)
+				END`
+	// Dropping a member that is no longer there does nothing, so the
+	// statement can be repeated after any transient error
+	return c.
+		setDatabase(&database).
+		ExecContext(withRetryWrites(ctx), cmd,
+			sql.Named("roleName", roleName),
+			sql.Named("memberName", memberName),
+		)
+}
+
+// getDatabaseRoleMembers returns the names of the direct members of roleName,
+// in order.
+func (c *Connector) getDatabaseRoleMembers(ctx context.Context, database, roleName string) ([]string, error) {
+	cmd := `SELECT m.name
+			FROM [sys].[database_role_members] drm
+			INNER JOIN [sys].[database_principals] r ON r.principal_id = drm.role_principal_id
+			INNER JOIN [sys].[database_principals] m ON m.principal_id = drm.member_principal_id
+			WHERE r.name = @roleName
+			ORDER BY m.name`
+	members := make([]string, 0)
+	err := c.
+		setDatabase(&database).
+		QueryContext(ctx, cmd,
+			func(r *sql.Rows) error {
+				for r.Next() {
+					var member string
+					if err := r.Scan(&member); err != nil {
+						return err
+					}
+					members = append(members, member)
+				}
+				return nil
+			},
+			sql.Named("roleName", roleName),
+		)
+	return members, err
+}
+
+// UpdateDatabaseRoleMembers adds the users and roles in members to roleName if
+// they are not members yet, and drops the other members of the role.
+func (c *Connector) UpdateDatabaseRoleMembers(ctx context.Context, database, roleName string, members []string) error {
+	current, err := c.getDatabaseRoleMembers(ctx, database, roleName)
+	if err != nil {
+		return err
+	}
+	wanted := make(map[string]bool, len(members))
+	for _, member := range members {
+		wanted[strings.ToLower(member)] = true
+	}
+	for _, member := range current {
+		if wanted[strings.ToLower(member)] {
+			delete(wanted, strings.ToLower(member))
+			continue
+		}
+		if err := c.DropDatabaseRoleMember(ctx, database, roleName, member); err != nil {
+			return err
+		}
+	}
+	for _, member := range members {
+		if !wanted[strings.ToLower(member)] {
+			continue
+		}
+		delete(wanted, strings.ToLower(member))
+		if err := c.AddDatabaseRoleMember(ctx, database, roleName, member); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+)
+
+func TestUpdateDatabaseRoleMembers(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	fake.addRows([]string{"name"}, []driver.Value{"app_user"}, []driver.Value{"reporting"})
+
+	if err := connector.UpdateDatabaseRoleMembers(context.Background(), "db", "readers", []string{"Reporting", "etl_user"}); err != nil {
+		t.Fatal(err)
+	}
+
+	fake.expectStatements(t,
+		fakeStatement{
+			query: "WHERE r.name = @roleName",
+			args:  map[string]interface{}{"roleName": "readers"},
+		},
+		fakeStatement{
+			query: "' DROP MEMBER ' + QuoteName(@memberName)",
+			args:  map[string]interface{}{"roleName": "readers", "memberName": "app_user"},
+		},
+		fakeStatement{
+			query: "' ADD MEMBER ' + QuoteName(@memberName)",
+			args:  map[string]interface{}{"roleName": "readers", "memberName": "etl_user"},
+		},
+	)
+}
+
+func TestGetDatabaseRoleMember_NotFound(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+
+	member, err := connector.GetDatabaseRoleMember(context.Background(), "db", "readers", "app_user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if member != nil {
+		t.Errorf("expected no member, got %+v", member)
+	}
+	fake.expectStatements(t, fakeStatement{
+		query: "AND m.name = @memberName",
+		args:  map[string]interface{}{"roleName": "readers", "memberName": "app_user"},
+	})
+}
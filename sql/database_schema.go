@@ -8,12 +8,16 @@ import (
 )
 
 func (c *Connector) GetDatabaseSchema(ctx context.Context, database, schemaName string) (*model.DatabaseSchema, error) {
+	caps, err := c.setDatabase(&database).GetServerCapabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
 	cmd := `SELECT
 				dp1.schema_id,
 				dp1.name,
 				dp1.principal_id,
 				CASE
-					WHEN @@VERSION LIKE 'Microsoft SQL Azure%'
+					WHEN @azure = 1
 						AND @database = 'master'
 						AND (@ownerName = 'dbo' OR @ownerName = '') THEN ''
 					ELSE dp2.name
@@ -23,7 +27,7 @@ func (c *Connector) GetDatabaseSchema(ctx context.Context, database, schemaName
 				ON dp1.principal_id = dp2.principal_id
 			WHERE dp1.name = @schemaName`
 	var sqlschema model.DatabaseSchema
-	err := c.
+	err = c.
 		setDatabase(&database).
 		QueryRowContext(ctx, cmd,
 			func(r *sql.Row) error {
@@ -32,6 +36,7 @@ func (c *Connector) GetDatabaseSchema(ctx context.Context, database, schemaName
 			sql.Named("database", database),
 			sql.Named("schemaName", schemaName),
 			sql.Named("ownerName", sqlschema.OwnerName),
+			sql.Named("azure", caps.IsAzure()),
 		)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -64,10 +69,14 @@ func (c *Connector) CreateDatabaseSchema(ctx context.Context, database, schemaNa
 }
 
 func (c *Connector) DeleteDatabaseSchema(ctx context.Context, database, schemaName string) error {
+	caps, err := c.setDatabase(&database).GetServerCapabilities(ctx)
+	if err != nil {
+		return err
+	}
 	cmd := `DECLARE @stmt nvarchar(max)
 			DECLARE @sql NVARCHAR(max)
 			DECLARE @user_name NVARCHAR(max) = (SELECT USER_NAME())
-			IF @@VERSION LIKE 'Microsoft SQL Azure%' AND @database = 'master'
+			IF @azure = 1 AND @database = 'master'
 				BEGIN
 					SET @stmt = 'IF EXISTS (SELECT 1 FROM [sys].[schemas] WHERE [name] = ' + QuoteName(@schemaName, '''') + ') ' +
 								'DROP SCHEMA ' + @schemaName
@@ -87,6 +96,7 @@ func (c *Connector) DeleteDatabaseSchema(ctx context.Context, database, schemaNa
 		ExecContext(ctx, cmd,
 			sql.Named("database", database),
 			sql.Named("schemaName", schemaName),
+			sql.Named("azure", caps.IsAzure()),
 		)
 }
 
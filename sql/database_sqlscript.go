@@ -9,22 +9,12 @@ import (
 	"github.com/pkg/errors"
 )
 
-// DataBaseExecuteScript executes a SQL script in the specified database
+// DataBaseExecuteScript executes a SQL script in the specified database.
+// Scripts are always executed as statements, even those starting with
+// SELECT, as they may still write, e.g. SELECT ... INTO.
 func (c *Connector) DataBaseExecuteScript(ctx context.Context, database string, script string) error {
-	if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(script)), "SELECT") {
-		var exists int
-		err := c.
-			setDatabase(&database).
-			QueryRowContext(ctx, script,
-				func(r *sql.Row) error {
-					return r.Scan(&exists)
-				},
-			)
-		if err == sql.ErrNoRows {
-			return fmt.Errorf("no rows returned from verification query")
-		}
-		return err
-	}
+	// Batches of a script are not necessarily idempotent, so they are not retried
+	ctx = withoutRetry(ctx)
 
 	// Split the script into batches
 	batches := splitBatches(script)
@@ -51,6 +41,23 @@ func (c *Connector) DataBaseExecuteScript(ctx context.Context, database string,
 	return nil
 }
 
+// DataBaseVerifyObject runs the verification query of a script in the
+// specified database, returning an error if it returns no rows.
+func (c *Connector) DataBaseVerifyObject(ctx context.Context, database string, query string) error {
+	var exists int
+	err := c.
+		setDatabase(&database).
+		QueryRowContext(ctx, query,
+			func(r *sql.Row) error {
+				return r.Scan(&exists)
+			},
+		)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("no rows returned from verification query")
+	}
+	return err
+}
+
 // splitBatches splits a SQL script into individual batches based on GO statements
 func splitBatches(script string) []string {
 	// First normalize line endings
@@ -0,0 +1,123 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+)
+
+// sqlcmdVariableChars are the characters that can not be part of the name of
+// a SQLCMD variable.
+var sqlcmdVariableChars = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// objectParams are the parameters naming the object a statement changes, in
+// order of preference, which qualify the SQLCMD variables of its secrets.
+var objectParams = []string{"name", "username", "loginName", "roleName", "credentialname", "identityname"}
+
+// emitMu serializes the statements written by all connectors of the process,
+// so batches of concurrent resources are not interleaved.
+var emitMu sync.Mutex
+
+// emitStatement writes statement to the SQL output of the connector instead
+// of executing it, as a batch that declares its parameters. Sensitive
+// parameters become SQLCMD variables named after the resource type, the
+// object and the parameter, and secrets written into the statement or its
+// parameters are redacted.
+func (c *Connector) emitStatement(ctx context.Context, statement string, args []interface{}) error {
+	var b strings.Builder
+	op, ok := model.OperationFromContext(ctx)
+	if ok {
+		if op.Emitted != nil {
+			op.Emitted.Store(true)
+		}
+		fmt.Fprintf(&b, "-- %s %s", op.Resource, op.Action)
+		if op.ID != "" {
+			fmt.Fprintf(&b, " %s", op.ID)
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "-- server: %s\n", c.serverName())
+	if c.Database != "" {
+		fmt.Fprintf(&b, "USE [%s];\nGO\n", strings.ReplaceAll(c.Database, "]", "]]"))
+	}
+	for i, arg := range args {
+		name, value := fmt.Sprintf("p%d", i+1), arg
+		if named, ok := arg.(sql.NamedArg); ok {
+			name, value = named.Name, named.Value
+		}
+		sqlType, literal := sqlLiteral(value)
+		if sensitiveParam.MatchString(name) {
+			literal = fmt.Sprintf("N'$(%s)'", sqlcmdVariable(op.Resource, args, name))
+		}
+		fmt.Fprintf(&b, "DECLARE @%s %s = %s;\n", name, sqlType, literal)
+	}
+	b.WriteString(strings.TrimSpace(maskInlineSecrets(statement)))
+	b.WriteString("\nGO\n\n")
+
+	emitMu.Lock()
+	defer emitMu.Unlock()
+	_, err := io.WriteString(c.sqlOutput, b.String())
+	return err
+}
+
+// sqlcmdVariable returns the name of the SQLCMD variable of the sensitive
+// parameter name, e.g. mssql_login_app_password for the password of login app,
+// so the secrets of different resources in one output can be told apart.
+func sqlcmdVariable(resource string, args []interface{}, name string) string {
+	var parts []string
+	for _, part := range []string{resource, objectName(args), name} {
+		if part = strings.Trim(sqlcmdVariableChars.ReplaceAllString(part, "_"), "_"); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "_")
+}
+
+// objectName returns the value of the first of objectParams in args.
+func objectName(args []interface{}) string {
+	for _, param := range objectParams {
+		for _, arg := range args {
+			if named, ok := arg.(sql.NamedArg); ok && named.Name == param {
+				if value, ok := named.Value.(string); ok {
+					return value
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func (c *Connector) serverName() string {
+	if c.Instance != "" {
+		return c.Host + `\` + c.Instance
+	}
+	return c.Host + ":" + c.Port
+}
+
+// sqlLiteral returns the T-SQL type and literal of a statement parameter.
+func sqlLiteral(value interface{}) (string, string) {
+	switch v := value.(type) {
+	case nil:
+		return "nvarchar(max)", "NULL"
+	case string:
+		return "nvarchar(max)", "N'" + strings.ReplaceAll(maskInlineSecrets(v), "'", "''") + "'"
+	case bool:
+		if v {
+			return "bit", "1"
+		}
+		return "bit", "0"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return "bigint", fmt.Sprintf("%d", v)
+	case []byte:
+		return "varbinary(max)", "0x" + strings.ToUpper(hex.EncodeToString(v))
+	default:
+		return "nvarchar(max)", "N'" + strings.ReplaceAll(fmt.Sprint(v), "'", "''") + "'"
+	}
+}
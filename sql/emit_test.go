@@ -0,0 +1,105 @@
+package sql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+)
+
+func TestExecContext_SQLOutput(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	var output bytes.Buffer
+	connector.sqlOutput = &output
+
+	op := model.Operation{Resource: "mssql_login", Action: "create", Emitted: &atomic.Bool{}}
+	ctx := model.WithOperation(context.Background(), op)
+	if err := connector.CreateLogin(ctx, "o'brien", "valueIsH8kd$¡", "", "db", ""); err != nil {
+		t.Fatal(err)
+	}
+	if !op.Emitted.Load() {
+		t.Error("expected the operation to be marked as emitted")
+	}
+
+	fake.expectStatements(t)
+	batch := output.String()
+	for _, expected := range []string{
+		"-- mssql_login create\n-- server: " + t.Name() + ":1433\nUSE [master];\nGO\n",
+		"DECLARE @name nvarchar(max) = N'o''brien';\n",
+		"DECLARE @password nvarchar(max) = N'$(mssql_login_o_brien_password)';\n",
+		"DECLARE @defaultDatabase nvarchar(max) = N'db';\n",
+		"DECLARE @azureDatabase bit = 0;\n",
+		"SET @sql = 'CREATE LOGIN ' + QuoteName(@name)",
+	} {
+		if !strings.Contains(batch, expected) {
+			t.Errorf("expected the output to contain %q:\n%s", expected, batch)
+		}
+	}
+	if strings.Contains(batch, "valueIsH8kd") {
+		t.Errorf("expected the password to be redacted:\n%s", batch)
+	}
+	if !strings.HasSuffix(batch, "\nGO\n\n") {
+		t.Errorf("expected the batch to end with GO:\n%s", batch)
+	}
+}
+
+func TestExecContext_SQLOutputScript(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	var output bytes.Buffer
+	connector.sqlOutput = &output
+
+	script := "CREATE LOGIN [app] WITH PASSWORD = 'valueIsH8kd$¡'\nGO\nCREATE USER [app] FOR LOGIN [app]"
+	if err := connector.DataBaseExecuteScript(context.Background(), "db", script); err != nil {
+		t.Fatal(err)
+	}
+
+	fake.expectStatements(t)
+	batches := output.String()
+	if strings.Count(batches, "USE [db];") != 2 {
+		t.Errorf("expected two batches in db:\n%s", batches)
+	}
+	if !strings.Contains(batches, "WITH PASSWORD = ''***''") {
+		t.Errorf("expected the inline password to be redacted:\n%s", batches)
+	}
+	if strings.Contains(batches, "valueIsH8kd") {
+		t.Errorf("expected the password to be redacted:\n%s", batches)
+	}
+}
+
+func TestExecContext_SQLOutputSelectScript(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	var output bytes.Buffer
+	connector.sqlOutput = &output
+
+	// Scripts starting with SELECT may still write, so they are emitted too.
+	if err := connector.DataBaseExecuteScript(context.Background(), "db", "SELECT * INTO t2 FROM t"); err != nil {
+		t.Fatal(err)
+	}
+
+	fake.expectStatements(t)
+	if batches := output.String(); !strings.Contains(batches, "N'SELECT * INTO t2 FROM t'") {
+		t.Errorf("expected the script in the output:\n%s", batches)
+	}
+}
+
+func TestSqlcmdVariable(t *testing.T) {
+	for _, tc := range []struct {
+		resource string
+		args     []interface{}
+		name     string
+		expected string
+	}{
+		{"mssql_login", []interface{}{sql.Named("name", "app"), sql.Named("password", "x")}, "password", "mssql_login_app_password"},
+		{"mssql_user", []interface{}{sql.Named("database", "db"), sql.Named("username", "app-user")}, "password", "mssql_user_app_user_password"},
+		{"mssql_database_credential", []interface{}{sql.Named("credentialname", "[cred]"), sql.Named("secret", "x")}, "secret", "mssql_database_credential_cred_secret"},
+		{"", []interface{}{sql.Named("password", "x")}, "password", "password"},
+	} {
+		if actual := sqlcmdVariable(tc.resource, tc.args, tc.name); actual != tc.expected {
+			t.Errorf("expected %s, got %s", tc.expected, actual)
+		}
+	}
+}
@@ -22,13 +22,20 @@ func (c *Connector) GetEntraIDLogin(ctx context.Context, name string) (*model.En
 		}
 		return nil, err
 	}
+	if login.ServerRoles, err = c.getServerRoles(ctx, name); err != nil {
+		return nil, err
+	}
 	return &login, nil
 }
 
 func (c *Connector) CreateEntraIDLogin(ctx context.Context, name, objectId string) error {
+	caps, err := c.GetServerCapabilities(ctx)
+	if err != nil {
+		return err
+	}
 	cmd := `DECLARE @stmt nvarchar(max)
 			SET @stmt = 'CREATE LOGIN ' + QuoteName(@name) + ' FROM EXTERNAL PROVIDER'
-			IF @@VERSION LIKE 'Microsoft SQL Azure%'
+			IF @azure = 1
 				BEGIN
 					IF @objectId != ''
 						BEGIN
@@ -40,6 +47,7 @@ func (c *Connector) CreateEntraIDLogin(ctx context.Context, name, objectId strin
 		ExecContext(ctx, cmd,
 			sql.Named("name", name),
 			sql.Named("objectId", objectId),
+			sql.Named("azure", caps.IsAzure()),
 		)
 }
 
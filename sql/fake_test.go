@@ -0,0 +1,194 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+)
+
+// fakeServer is a driver.Connector that records the statements it is sent
+// and answers queries with the results queued by the test, in order. The
+// server capabilities queries are answered from capabilities and not recorded.
+type fakeServer struct {
+	mu           sync.Mutex
+	capabilities model.ServerCapabilities
+	detections   int
+	statements   []fakeStatement
+	results      []fakeResult
+}
+
+type fakeStatement struct {
+	query string
+	args  map[string]interface{}
+}
+
+type fakeResult struct {
+	columns []string
+	rows    [][]driver.Value
+	err     error
+}
+
+// newFakeConnector returns a connector to a fake SQL Server 2022 that is
+// detected once per test.
+func newFakeConnector(t *testing.T) (*Connector, *fakeServer) {
+	fake := &fakeServer{
+		capabilities: model.ServerCapabilities{
+			EngineEdition:  model.EngineEditionEnterprise,
+			ProductVersion: "16.0.1000.6",
+			Edition:        "Developer Edition (64-bit)",
+		},
+	}
+	return &Connector{
+		Host:    t.Name(),
+		Port:    "1433",
+		Timeout: time.Second,
+		Retry:   model.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: time.Millisecond},
+		Driver:  fake,
+	}, fake
+}
+
+// addRows queues the result of the next query.
+func (f *fakeServer) addRows(columns []string, rows ...[]driver.Value) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results = append(f.results, fakeResult{columns: columns, rows: rows})
+}
+
+// addError queues the error of the next statement.
+func (f *fakeServer) addError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results = append(f.results, fakeResult{err: err})
+}
+
+// expectStatements fails the test unless the recorded statements contain
+// each fragment in turn and were sent with exactly the given parameters.
+func (f *fakeServer) expectStatements(t *testing.T, expected ...fakeStatement) {
+	t.Helper()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.statements) != len(expected) {
+		t.Fatalf("expected %d statements, got %d: %v", len(expected), len(f.statements), f.statements)
+	}
+	for i, e := range expected {
+		s := f.statements[i]
+		if !strings.Contains(s.query, e.query) {
+			t.Errorf("statement %d does not contain %q:\n%s", i+1, e.query, s.query)
+		}
+		if !reflect.DeepEqual(s.args, e.args) {
+			t.Errorf("statement %d parameters: expected %v, got %v", i+1, e.args, s.args)
+		}
+	}
+}
+
+func (f *fakeServer) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{server: f}, nil
+}
+
+func (f *fakeServer) Driver() driver.Driver {
+	return fakeDriver{server: f}
+}
+
+func (f *fakeServer) run(query string, args []driver.NamedValue) (fakeResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if strings.Contains(query, "SERVERPROPERTY('EngineEdition')") {
+		f.detections++
+		c := f.capabilities
+		return fakeResult{
+			columns: []string{"engine_edition", "product_version", "edition", "is_hadr_enabled"},
+			rows:    [][]driver.Value{{int64(c.EngineEdition), c.ProductVersion, c.Edition, c.HadrEnabled}},
+		}, nil
+	}
+	if strings.Contains(query, "is_contained") {
+		return fakeResult{columns: []string{"is_contained"}, rows: [][]driver.Value{{f.capabilities.ContainedAG}}}, nil
+	}
+
+	named := make(map[string]interface{}, len(args))
+	for _, arg := range args {
+		named[arg.Name] = arg.Value
+	}
+	f.statements = append(f.statements, fakeStatement{query: query, args: named})
+
+	if len(f.results) == 0 {
+		return fakeResult{}, nil
+	}
+	result := f.results[0]
+	f.results = f.results[1:]
+	return result, result.err
+}
+
+type fakeDriver struct {
+	server *fakeServer
+}
+
+func (d fakeDriver) Open(string) (driver.Conn, error) {
+	return &fakeConn{server: d.server}, nil
+}
+
+type fakeConn struct {
+	server *fakeServer
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, driver.ErrSkip
+}
+
+// CheckNamedValue accepts parameters of any type, as the real driver does.
+func (c *fakeConn) CheckNamedValue(*driver.NamedValue) error {
+	return nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if _, err := c.server.run(query, args); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	result, err := c.server.run(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &fakeRows{result: result}, nil
+}
+
+type fakeRows struct {
+	result fakeResult
+	next   int
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.result.columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.result.rows) {
+		return io.EOF
+	}
+	copy(dest, r.result.rows[r.next])
+	r.next++
+	return nil
+}
@@ -0,0 +1,167 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/pkg/errors"
+)
+
+// writeLockResource is the application lock taken in each database around
+// the changes of each resource operation when the write lock is enabled.
+const writeLockResource = "terraform-provider-mssql"
+
+// writeLimiter limits the statements executed concurrently by the provider
+// in each database, as concurrent role and permission changes in one
+// database deadlock.
+type writeLimiter struct {
+	mu    sync.Mutex
+	max   int
+	slots map[writeLimiterKey]chan struct{}
+}
+
+type writeLimiterKey struct {
+	host     string
+	port     string
+	instance string
+	database string
+}
+
+func newWriteLimiter(max int) *writeLimiter {
+	return &writeLimiter{max: max, slots: make(map[writeLimiterKey]chan struct{})}
+}
+
+// acquire waits for a free slot in the database of c, and returns the
+// function releasing it. A nil limiter or a maximum of 0 does not limit.
+func (l *writeLimiter) acquire(ctx context.Context, c *Connector) (func(), error) {
+	if l == nil || l.max <= 0 {
+		return func() {}, nil
+	}
+	key := writeLimiterKey{host: c.Host, port: c.Port, instance: c.Instance, database: c.Database}
+
+	l.mu.Lock()
+	slot, ok := l.slots[key]
+	if !ok {
+		slot = make(chan struct{}, l.max)
+		l.slots[key] = slot
+	}
+	l.mu.Unlock()
+
+	select {
+	case slot <- struct{}{}:
+		return func() { <-slot }, nil
+	default:
+	}
+	tflog.Debug(ctx, "waiting for other statements in the database to finish", map[string]interface{}{
+		"database": c.Database,
+	})
+	select {
+	case slot <- struct{}{}:
+		return func() { <-slot }, nil
+	case <-ctx.Done():
+		return nil, errors.Wrapf(ctx.Err(), "timed out waiting for %d concurrent statements in database [%s] to finish", l.max, c.Database)
+	}
+}
+
+// WriteLockError is returned when the application lock of the database could
+// not be acquired within the lock timeout.
+type WriteLockError struct {
+	Database string
+	Timeout  time.Duration
+	Result   int
+}
+
+func (e *WriteLockError) Error() string {
+	var reason string
+	switch e.Result {
+	case -1:
+		reason = fmt.Sprintf("timed out after %s, another Terraform run or client holds it", e.Timeout)
+	case -2:
+		reason = "the request was canceled"
+	case -3:
+		reason = "the request was chosen as a deadlock victim"
+	default:
+		reason = "a parameter validation or other call error occurred"
+	}
+	return fmt.Sprintf("unable to acquire application lock [%s] in database [%s]: %s (sp_getapplock returned %d)", writeLockResource, e.Database, reason, e.Result)
+}
+
+// writeLockConn returns a connection of db holding the exclusive application
+// lock of the database, and the function to call once the statement ran on
+// it. The lock is held until the end of the resource operation of ctx, if
+// any, so the statements reconciling a resource do not interleave with those
+// of another run; otherwise, e.g. without a shared pool, only for the
+// statement.
+func (c *Connector) writeLockConn(ctx context.Context, db *sql.DB) (*sql.Conn, func(), error) {
+	op, ok := model.OperationFromContext(ctx)
+	if !ok || op.Locks == nil || c.pool == nil {
+		return c.lock(ctx, db)
+	}
+	conn, err := op.Locks.Acquire(db, func() (interface{}, func(), error) {
+		return c.lock(ctx, db)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn.(*sql.Conn), func() {}, nil
+}
+
+// heldConn returns the connection of db holding the write lock for the
+// operation of ctx, if any, so its queries do not wait for another
+// connection of the pool.
+func heldConn(ctx context.Context, db *sql.DB) (*sql.Conn, bool) {
+	op, ok := model.OperationFromContext(ctx)
+	if !ok || op.Locks == nil {
+		return nil, false
+	}
+	conn, ok := op.Locks.Get(db)
+	if !ok {
+		return nil, false
+	}
+	return conn.(*sql.Conn), true
+}
+
+// lock takes the exclusive application lock of the database on a connection
+// of db, and returns the connection with the function releasing the lock and
+// the connection.
+func (c *Connector) lock(ctx context.Context, db *sql.DB) (*sql.Conn, func(), error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result int
+	err = conn.QueryRowContext(ctx,
+		`DECLARE @result int
+		EXEC @result = sp_getapplock @Resource = @resource, @LockMode = 'Exclusive', @LockOwner = 'Session', @LockTimeout = @lockTimeout
+		SELECT @result`,
+		sql.Named("resource", writeLockResource),
+		sql.Named("lockTimeout", c.WriteLockTimeout.Milliseconds()),
+	).Scan(&result)
+	if err != nil {
+		conn.Close()
+		return nil, nil, errors.Wrap(err, "failed to request the application lock")
+	}
+	if result < 0 {
+		conn.Close()
+		return nil, nil, &WriteLockError{Database: c.Database, Timeout: c.WriteLockTimeout, Result: result}
+	}
+	unlock := func() {
+		// The lock is released even if the operation was canceled, so the
+		// connection does not go back to the pool holding it.
+		_, err := conn.ExecContext(context.WithoutCancel(ctx),
+			"EXEC sp_releaseapplock @Resource = @resource, @LockOwner = 'Session'",
+			sql.Named("resource", writeLockResource),
+		)
+		if err != nil {
+			tflog.Warn(ctx, "failed to release the application lock", map[string]interface{}{"error": err.Error()})
+		}
+		conn.Close()
+	}
+	return conn, unlock, nil
+}
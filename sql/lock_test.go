@@ -0,0 +1,130 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+	"github.com/pkg/errors"
+)
+
+func TestWriteLimiter(t *testing.T) {
+	connector, _ := newFakeConnector(t)
+	connector.Database = "db"
+	limiter := newWriteLimiter(1)
+
+	release, err := limiter.acquire(context.Background(), connector)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other := *connector
+	other.Database = "other"
+	releaseOther, err := limiter.acquire(context.Background(), &other)
+	if err != nil {
+		t.Fatalf("expected another database not to wait, got %v", err)
+	}
+	releaseOther()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.acquire(ctx, connector); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected to wait for the database, got %v", err)
+	}
+
+	release()
+	release, err = limiter.acquire(context.Background(), connector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+}
+
+func TestExecContext_WriteLock(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	connector.WriteLock = true
+	connector.WriteLockTimeout = 5 * time.Second
+	fake.addRows([]string{"result"}, []driver.Value{int64(0)})
+
+	if err := connector.ExecContext(context.Background(), "CREATE ROLE [role]"); err != nil {
+		t.Fatal(err)
+	}
+
+	fake.expectStatements(t,
+		fakeStatement{
+			query: "EXEC @result = sp_getapplock @Resource = @resource, @LockMode = 'Exclusive', @LockOwner = 'Session'",
+			args:  map[string]interface{}{"resource": "terraform-provider-mssql", "lockTimeout": int64(5000)},
+		},
+		fakeStatement{query: "CREATE ROLE [role]", args: map[string]interface{}{}},
+		fakeStatement{
+			query: "EXEC sp_releaseapplock @Resource = @resource, @LockOwner = 'Session'",
+			args:  map[string]interface{}{"resource": "terraform-provider-mssql"},
+		},
+	)
+}
+
+func TestExecContext_WriteLockTimeout(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	connector.Database = "db"
+	connector.WriteLock = true
+	connector.WriteLockTimeout = 5 * time.Second
+	fake.addRows([]string{"result"}, []driver.Value{int64(-1)})
+
+	err := connector.ExecContext(context.Background(), "CREATE ROLE [role]")
+	var lockErr *WriteLockError
+	if !errors.As(err, &lockErr) {
+		t.Fatalf("expected a WriteLockError, got %v", err)
+	}
+	expected := "unable to acquire application lock [terraform-provider-mssql] in database [db]: timed out after 5s, another Terraform run or client holds it (sp_getapplock returned -1)"
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+	if len(fake.statements) != 1 {
+		t.Errorf("expected only the lock to be requested, got %v", fake.statements)
+	}
+}
+
+func TestExecContext_WriteLockPerOperation(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	connector.WriteLock = true
+	connector.WriteLockTimeout = 5 * time.Second
+	// A single connection, so the query of the operation must run on the one
+	// holding the lock.
+	connector.pool = newPool(1, 1)
+	defer connector.pool.close()
+	fake.addRows([]string{"result"}, []driver.Value{int64(0)})
+	fake.addRows(nil)
+	fake.addRows([]string{"count"}, []driver.Value{int64(1)})
+
+	locks := &model.LockSet{}
+	ctx, cancel := context.WithTimeout(model.WithOperation(context.Background(), model.Operation{Resource: "mssql_database_role", Action: "update", Locks: locks}), 5*time.Second)
+	defer cancel()
+	if err := connector.ExecContext(ctx, "ALTER ROLE [role] DROP MEMBER [a]"); err != nil {
+		t.Fatal(err)
+	}
+	var count int
+	if err := connector.QueryRowContext(ctx, "SELECT COUNT(*) FROM [sys].[database_role_members]", func(r *sql.Row) error { return r.Scan(&count) }); err != nil {
+		t.Fatal(err)
+	}
+	if err := connector.ExecContext(ctx, "ALTER ROLE [role] ADD MEMBER [b]"); err != nil {
+		t.Fatal(err)
+	}
+	locks.Release()
+
+	fake.expectStatements(t,
+		fakeStatement{
+			query: "EXEC @result = sp_getapplock @Resource = @resource, @LockMode = 'Exclusive', @LockOwner = 'Session'",
+			args:  map[string]interface{}{"resource": "terraform-provider-mssql", "lockTimeout": int64(5000)},
+		},
+		fakeStatement{query: "ALTER ROLE [role] DROP MEMBER [a]", args: map[string]interface{}{}},
+		fakeStatement{query: "SELECT COUNT(*) FROM [sys].[database_role_members]", args: map[string]interface{}{}},
+		fakeStatement{query: "ALTER ROLE [role] ADD MEMBER [b]", args: map[string]interface{}{}},
+		fakeStatement{
+			query: "EXEC sp_releaseapplock @Resource = @resource, @LockOwner = 'Session'",
+			args:  map[string]interface{}{"resource": "terraform-provider-mssql"},
+		},
+	)
+}
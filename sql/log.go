@@ -0,0 +1,116 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// sensitiveParam matches the names of statement parameters whose values are
+// never logged.
+var sensitiveParam = regexp.MustCompile(`(?i)password|secret|token`)
+
+// secretAssignment matches code assigning the string literal that follows it
+// to a password or secret, e.g. WITH PASSWORD = N.
+var secretAssignment = regexp.MustCompile(`(?i)(password|secret)\s*=\s*N?$`)
+
+// nestedSecret matches secrets written into a string literal holding dynamic
+// SQL, where their quotes are doubled.
+var nestedSecret = regexp.MustCompile(`(?i)(password|secret)\s*=\s*N?''(''''|[^'])*''`)
+
+// traceStatement logs a statement and its parameters at TRACE level, to
+// terraform-plugin-log and to the provider log file, if any, and starts its
+// span.
+func (c *Connector) traceStatement(ctx context.Context, statement string, args []interface{}) (context.Context, trace.Span) {
+	statement, params := maskStatement(statement, args)
+
+	tflog.Trace(ctx, "executing statement", map[string]interface{}{
+		"statement": statement,
+		"params":    params,
+	})
+	if c.logFile != nil {
+		c.logFile.Trace().Str("statement", statement).Interface("params", params).Msg("executing statement")
+	}
+
+	return c.startSpan(ctx, "sql.statement", attribute.String("db.query.text", statement))
+}
+
+// maskStatement returns statement and its parameters by name, with the
+// values of sensitive parameters and inline secrets masked.
+func maskStatement(statement string, args []interface{}) (string, map[string]interface{}) {
+	params := make(map[string]interface{}, len(args))
+	for i, arg := range args {
+		name, value := fmt.Sprintf("p%d", i+1), arg
+		if named, ok := arg.(sql.NamedArg); ok {
+			name, value = named.Name, named.Value
+		}
+		if sensitiveParam.MatchString(name) {
+			value = "***"
+		}
+		params["@"+name] = value
+	}
+	return maskInlineSecrets(statement), params
+}
+
+// maskInlineSecrets returns statement with the secrets written into it, e.g.
+// by a user supplied script, masked. Only string literals assigned to a
+// password or secret are masked, so dynamic SQL quoting a password parameter
+// with QuoteName is left intact.
+func maskInlineSecrets(statement string) string {
+	var b strings.Builder
+	code := 0
+	for i := 0; i < len(statement); {
+		switch {
+		case strings.HasPrefix(statement[i:], "--"):
+			i = skipPast(statement, i+2, "\n")
+		case strings.HasPrefix(statement[i:], "/*"):
+			i = skipPast(statement, i+2, "*/")
+		case statement[i] == '\'':
+			end := literalEnd(statement, i)
+			literal := statement[i:end]
+			if secretAssignment.MatchString(statement[code:i]) {
+				literal = "'***'"
+			} else {
+				literal = nestedSecret.ReplaceAllString(literal, "$1 = ''***''")
+			}
+			b.WriteString(statement[code:i])
+			b.WriteString(literal)
+			i, code = end, end
+		default:
+			i++
+		}
+	}
+	b.WriteString(statement[code:])
+	return b.String()
+}
+
+// skipPast returns the index after the first terminator in s at or after i,
+// or the end of s.
+func skipPast(s string, i int, terminator string) int {
+	if end := strings.Index(s[i:], terminator); end >= 0 {
+		return i + end + len(terminator)
+	}
+	return len(s)
+}
+
+// literalEnd returns the index after the string literal starting at i, or the
+// end of s if it is not terminated.
+func literalEnd(s string, i int) int {
+	for j := i + 1; j < len(s); j++ {
+		if s[j] != '\'' {
+			continue
+		}
+		if j+1 < len(s) && s[j+1] == '\'' {
+			j++
+			continue
+		}
+		return j + 1
+	}
+	return len(s)
+}
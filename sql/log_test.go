@@ -0,0 +1,80 @@
+package sql
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+func TestMaskStatement(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		statement string
+		args      []interface{}
+		expected  string
+		params    map[string]interface{}
+	}{
+		{
+			name:      "named parameters",
+			statement: "EXEC sp_example @name, @password, @secret",
+			args:      []interface{}{sql.Named("name", "app"), sql.Named("password", "valueIsH8kd$¡"), sql.Named("secret", "s3cret")},
+			expected:  "EXEC sp_example @name, @password, @secret",
+			params:    map[string]interface{}{"@name": "app", "@password": "***", "@secret": "***"},
+		},
+		{
+			name:      "access token parameter",
+			statement: "SELECT 1",
+			args:      []interface{}{sql.Named("accessToken", "eyJ0eXAi")},
+			expected:  "SELECT 1",
+			params:    map[string]interface{}{"@accessToken": "***"},
+		},
+		{
+			name:      "positional parameters",
+			statement: "SELECT @p1",
+			args:      []interface{}{"app"},
+			expected:  "SELECT @p1",
+			params:    map[string]interface{}{"@p1": "app"},
+		},
+		{
+			name:      "inline literal",
+			statement: "CREATE LOGIN [app] WITH PASSWORD = 'valueIsH8kd$¡', CHECK_POLICY = OFF",
+			expected:  "CREATE LOGIN [app] WITH PASSWORD = '***', CHECK_POLICY = OFF",
+			params:    map[string]interface{}{},
+		},
+		{
+			name:      "inline unicode literal with quotes",
+			statement: "CREATE CREDENTIAL [c] WITH IDENTITY = 'app', SECRET = N'it''s'",
+			expected:  "CREATE CREDENTIAL [c] WITH IDENTITY = 'app', SECRET = N'***'",
+			params:    map[string]interface{}{},
+		},
+		{
+			name:      "inline literal in dynamic SQL",
+			statement: "EXEC('CREATE LOGIN [app] WITH PASSWORD = ''valueIsH8kd$¡''')",
+			expected:  "EXEC('CREATE LOGIN [app] WITH PASSWORD = ''***''')",
+			params:    map[string]interface{}{},
+		},
+		{
+			name:      "inline literal after comment",
+			statement: "-- don't log this\nCREATE LOGIN [app] WITH PASSWORD = 'valueIsH8kd$¡'",
+			expected:  "-- don't log this\nCREATE LOGIN [app] WITH PASSWORD = '***'",
+			params:    map[string]interface{}{},
+		},
+		{
+			name:      "QuoteName of a password parameter",
+			statement: "SET @sql = 'CREATE APPLICATION ROLE ' + QuoteName(@roleName) + ' WITH PASSWORD = ' + QuoteName(@password, '''')",
+			args:      []interface{}{sql.Named("roleName", "app"), sql.Named("password", "valueIsH8kd$¡")},
+			expected:  "SET @sql = 'CREATE APPLICATION ROLE ' + QuoteName(@roleName) + ' WITH PASSWORD = ' + QuoteName(@password, '''')",
+			params:    map[string]interface{}{"@roleName": "app", "@password": "***"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			statement, params := maskStatement(tc.statement, tc.args)
+			if statement != tc.expected {
+				t.Errorf("expected statement %q, got %q", tc.expected, statement)
+			}
+			if !reflect.DeepEqual(params, tc.params) {
+				t.Errorf("expected params %v, got %v", tc.params, params)
+			}
+		})
+	}
+}
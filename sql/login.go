@@ -22,10 +22,18 @@ func (c *Connector) GetLogin(ctx context.Context, name string) (*model.Login, er
 		}
 		return nil, err
 	}
+	if login.ServerRoles, err = c.getServerRoles(ctx, name); err != nil {
+		return nil, err
+	}
 	return &login, nil
 }
 
 func (c *Connector) CreateLogin(ctx context.Context, name, password, sid, defaultDatabase, defaultLanguage string) error {
+	database := "master"
+	caps, err := c.setDatabase(&database).GetServerCapabilities(ctx)
+	if err != nil {
+		return err
+	}
 	cmd := `DECLARE @sql nvarchar(max)
 			SET @sql = 'CREATE LOGIN ' + QuoteName(@name) + ' ' +
 						'WITH PASSWORD = ' + QuoteName(@password, '''')
@@ -33,7 +41,7 @@ func (c *Connector) CreateLogin(ctx context.Context, name, password, sid, defaul
 				BEGIN
 					SET @sql = @sql + ', SID = ' + CONVERT(VARCHAR(85), @sid, 1)
 				END
-			IF @@VERSION NOT LIKE 'Microsoft SQL Azure%'
+			IF @azureDatabase = 0
 				BEGIN
 					IF @defaultDatabase = '' SET @defaultDatabase = 'master'
 					IF NOT @defaultDatabase = 'master'
@@ -47,7 +55,6 @@ func (c *Connector) CreateLogin(ctx context.Context, name, password, sid, defaul
 						END
 				END
 			EXEC (@sql)`
-	database := "master"
 	return c.
 		setDatabase(&database).
 		ExecContext(ctx, cmd,
@@ -56,14 +63,19 @@ func (c *Connector) CreateLogin(ctx context.Context, name, password, sid, defaul
 			sql.Named("sid", sid),
 			sql.Named("defaultDatabase", defaultDatabase),
 			sql.Named("defaultLanguage", defaultLanguage),
+			sql.Named("azureDatabase", caps.IsAzureSQLDatabase()),
 		)
 }
 
 func (c *Connector) UpdateLogin(ctx context.Context, name, password, defaultDatabase, defaultLanguage string) error {
+	caps, err := c.GetServerCapabilities(ctx)
+	if err != nil {
+		return err
+	}
 	cmd := `DECLARE @sql nvarchar(max)
 			SET @sql = 'ALTER LOGIN ' + QuoteName(@name) + ' ' +
 						'WITH PASSWORD = ' + QuoteName(@password, '''')
-			IF @@VERSION NOT LIKE 'Microsoft SQL Azure%'
+			IF @azureDatabase = 0
 				BEGIN
 					IF @defaultDatabase = '' SET @defaultDatabase = 'master'
 					IF NOT @defaultDatabase IN (SELECT default_database_name FROM [master].[sys].[sql_logins] WHERE [name] = @name)
@@ -84,6 +96,7 @@ func (c *Connector) UpdateLogin(ctx context.Context, name, password, defaultData
 			sql.Named("password", password),
 			sql.Named("defaultDatabase", defaultDatabase),
 			sql.Named("defaultLanguage", defaultLanguage),
+			sql.Named("azureDatabase", caps.IsAzureSQLDatabase()),
 		)
 }
 
@@ -0,0 +1,80 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+)
+
+func TestCreateLogin(t *testing.T) {
+	tests := []struct {
+		edition       int
+		azureDatabase bool
+	}{
+		{model.EngineEditionEnterprise, false},
+		{model.EngineEditionManagedInstance, false},
+		{model.EngineEditionSQLDatabase, true},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("edition %d", test.edition), func(t *testing.T) {
+			connector, fake := newFakeConnector(t)
+			fake.capabilities.EngineEdition = test.edition
+
+			if err := connector.CreateLogin(context.Background(), "login", "valueIsH8kd$¡", "", "db", "russian"); err != nil {
+				t.Fatal(err)
+			}
+
+			fake.expectStatements(t, fakeStatement{
+				query: "SET @sql = 'CREATE LOGIN ' + QuoteName(@name)",
+				args: map[string]interface{}{
+					"name":            "login",
+					"password":        "valueIsH8kd$¡",
+					"sid":             "",
+					"defaultDatabase": "db",
+					"defaultLanguage": "russian",
+					"azureDatabase":   test.azureDatabase,
+				},
+			})
+			if connector.Database != "master" {
+				t.Errorf("expected the login to be created in master, got %q", connector.Database)
+			}
+		})
+	}
+}
+
+func TestGetLogin(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	fake.addRows(
+		[]string{"principal_id", "name", "sid", "default_database_name", "default_language_name"},
+		[]driver.Value{int64(260), "login", "0x01", "master", "us_english"},
+	)
+	fake.addRows([]string{"name"}, []driver.Value{"dbcreator"}, []driver.Value{"securityadmin"})
+
+	login, err := connector.GetLogin(context.Background(), "login")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &model.Login{
+		PrincipalID:     260,
+		LoginName:       "login",
+		SIDStr:          "0x01",
+		DefaultDatabase: "master",
+		DefaultLanguage: "us_english",
+		ServerRoles:     []string{"dbcreator", "securityadmin"},
+	}
+	if !reflect.DeepEqual(login, expected) {
+		t.Errorf("expected %+v, got %+v", expected, login)
+	}
+	fake.expectStatements(t, fakeStatement{
+		query: "FROM [master].[sys].[sql_logins] WHERE [name] = @name",
+		args:  map[string]interface{}{"name": "login"},
+	}, fakeStatement{
+		query: "FROM [master].[sys].[server_role_members] srm",
+		args:  map[string]interface{}{"memberName": "login"},
+	})
+}
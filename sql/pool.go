@@ -0,0 +1,130 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// pool shares one *sql.DB per server, database and identity for the lifetime
+// of a configured provider, so resources don't pay for a new login handshake
+// on every statement.
+type pool struct {
+	mu           sync.Mutex
+	entries      map[poolKey]*poolEntry
+	maxOpenConns int
+	maxIdleConns int
+}
+
+type poolEntry struct {
+	mu sync.Mutex
+	db *sql.DB
+}
+
+type poolKey struct {
+	host       string
+	port       string
+	instance   string
+	database   string
+	login      LoginUser
+	azureLogin AzureLogin
+	fedauthMSI FedauthMSI
+	msi        bool
+	workload   WorkloadIdentity
+	token      string
+	tls        TLSSettings
+	options    ConnectionOptions
+}
+
+func newPool(maxOpenConns, maxIdleConns int) *pool {
+	return &pool{
+		entries:      make(map[poolKey]*poolEntry),
+		maxOpenConns: maxOpenConns,
+		maxIdleConns: maxIdleConns,
+	}
+}
+
+func (c *Connector) poolKey() poolKey {
+	key := poolKey{
+		host:     c.Host,
+		port:     c.Port,
+		instance: c.Instance,
+		database: c.Database,
+		token:    c.Token,
+		tls:      c.TLS,
+		options:  c.Options,
+	}
+	if c.Login != nil {
+		key.login = *c.Login
+	}
+	if c.AzureLogin != nil {
+		key.azureLogin = *c.AzureLogin
+	}
+	if c.FedauthMSI != nil {
+		key.fedauthMSI = *c.FedauthMSI
+		key.msi = true
+	}
+	if c.WorkloadIdentity != nil {
+		key.workload = *c.WorkloadIdentity
+	}
+	return key
+}
+
+func (p *pool) entry(key poolKey) *poolEntry {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e, ok := p.entries[key]
+	if !ok {
+		e = &poolEntry{}
+		p.entries[key] = e
+	}
+	return e
+}
+
+// get returns the *sql.DB of c, connecting on first use. Only the callers
+// for the same key wait for the connection to be established.
+func (p *pool) get(ctx context.Context, c *Connector) (*sql.DB, error) {
+	e := p.entry(c.poolKey())
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.db != nil {
+		return e.db, nil
+	}
+
+	conn, err := c.connector()
+	if err != nil {
+		return nil, err
+	}
+	db, err := connectLoop(ctx, conn, c.Timeout, c.retryPolicy())
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(p.maxOpenConns)
+	db.SetMaxIdleConns(p.maxIdleConns)
+
+	e.db = db
+	return db, nil
+}
+
+func (p *pool) close() error {
+	p.mu.Lock()
+	entries := p.entries
+	p.entries = make(map[poolKey]*poolEntry)
+	p.mu.Unlock()
+
+	var firstErr error
+	for _, e := range entries {
+		e.mu.Lock()
+		if e.db != nil {
+			if err := e.db.Close(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+			e.db = nil
+		}
+		e.mu.Unlock()
+	}
+	return firstErr
+}
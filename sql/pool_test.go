@@ -0,0 +1,81 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+// blockingServer is a fakeServer whose connections wait until release is
+// closed, announcing each attempt on connecting.
+type blockingServer struct {
+	*fakeServer
+	connecting chan struct{}
+	release    chan struct{}
+}
+
+func (b *blockingServer) Connect(ctx context.Context) (driver.Conn, error) {
+	b.connecting <- struct{}{}
+	<-b.release
+	return b.fakeServer.Connect(ctx)
+}
+
+func (b *blockingServer) Driver() driver.Driver {
+	return b.fakeServer.Driver()
+}
+
+func TestPool_ConnectsKeysIndependently(t *testing.T) {
+	p := newPool(1, 1)
+	defer p.close()
+
+	slow, fake := newFakeConnector(t)
+	blocking := &blockingServer{fakeServer: fake, connecting: make(chan struct{}, 1), release: make(chan struct{})}
+	slow.Driver, slow.Database = blocking, "slow"
+	fast, _ := newFakeConnector(t)
+	fast.Database = "fast"
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := p.get(context.Background(), slow)
+		done <- err
+	}()
+	<-blocking.connecting
+
+	got := make(chan error, 1)
+	go func() {
+		_, err := p.get(context.Background(), fast)
+		got <- err
+	}()
+	select {
+	case err := <-got:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the connection to another database not to wait for the slow one")
+	}
+
+	close(blocking.release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPool_SharesConnections(t *testing.T) {
+	p := newPool(1, 1)
+	defer p.close()
+
+	connector, _ := newFakeConnector(t)
+	first, err := p.get(context.Background(), connector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := p.get(context.Background(), connector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Error("expected the same *sql.DB for the same server, database and identity")
+	}
+}
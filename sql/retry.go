@@ -0,0 +1,148 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"math/rand"
+	"net"
+	"time"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	mssql "github.com/microsoft/go-mssqldb"
+	"github.com/pkg/errors"
+)
+
+// DefaultRetryPolicy is used by connectors without a configured retry policy.
+var DefaultRetryPolicy = model.RetryPolicy{
+	MaxAttempts: 10,
+	Backoff:     time.Second,
+	MaxBackoff:  30 * time.Second,
+	Jitter:      0.2,
+}
+
+// transientErrors are the SQL Server error numbers after which the same
+// statement or login can succeed when tried again.
+var transientErrors = map[int32]string{
+	1205:  "deadlock victim",
+	4060:  "database unavailable",
+	40197: "service error processing the request",
+	40501: "service busy",
+	40613: "database not currently available",
+	42108: "serverless database is resuming",
+	42109: "serverless database is paused",
+	49918: "not enough resources to process the request",
+	49919: "too many create or update operations",
+	49920: "too many operations in progress",
+}
+
+// rolledBackErrors are the transient errors after which a statement is known
+// not to have changed anything: the deadlock victim is rolled back, and the
+// others reject the request before it is executed.
+var rolledBackErrors = map[int32]bool{
+	1205:  true,
+	4060:  true,
+	40501: true,
+	40613: true,
+	42108: true,
+	42109: true,
+	49918: true,
+	49919: true,
+	49920: true,
+}
+
+// isTransient reports whether err is worth retrying: a transient SQL Server
+// error, or a network error while the server is unreachable.
+func isTransient(err error) bool {
+	var sqlErr mssql.Error
+	if errors.As(err, &sqlErr) {
+		_, ok := transientErrors[sqlErr.Number]
+		return ok
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, driver.ErrBadConn)
+}
+
+// isRolledBack reports whether a write that failed with err is worth
+// retrying: it is transient and the statement did not take effect. Network
+// errors are not, as the statement may have been executed before the
+// connection broke.
+func isRolledBack(err error) bool {
+	var sqlErr mssql.Error
+	return errors.As(err, &sqlErr) && rolledBackErrors[sqlErr.Number]
+}
+
+type noRetryKey struct{}
+
+type retryWritesKey struct{}
+
+// withoutRetry marks ctx so statements executed with it are tried only once,
+// for statements that are not safe to repeat, e.g. user supplied scripts.
+func withoutRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey{}, true)
+}
+
+// withRetryWrites marks ctx so statements executed with it are retried after
+// any transient error, like queries, for statements that are safe to repeat.
+func withRetryWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryWritesKey{}, true)
+}
+
+// writeRetryable returns the errors after which a statement changing the
+// server is retried, see withRetryWrites.
+func writeRetryable(ctx context.Context) func(error) bool {
+	if v, _ := ctx.Value(retryWritesKey{}).(bool); v {
+		return isTransient
+	}
+	return isRolledBack
+}
+
+func (c *Connector) retryPolicy() model.RetryPolicy {
+	if c.Retry.MaxAttempts > 0 {
+		return c.Retry
+	}
+	return DefaultRetryPolicy
+}
+
+// retry calls fn until it succeeds, fails with an error that is not
+// retryable, or the attempts of the policy are used up.
+func retry(ctx context.Context, policy model.RetryPolicy, retryable func(error) bool, fn func() error) error {
+	if v, _ := ctx.Value(noRetryKey{}).(bool); v {
+		return fn()
+	}
+
+	delay := policy.Backoff
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= policy.MaxAttempts || !retryable(err) {
+			return err
+		}
+		wait := jitter(delay, policy.Jitter)
+		tflog.Warn(ctx, "transient error, retrying", map[string]interface{}{
+			"attempt":      attempt,
+			"max_attempts": policy.MaxAttempts,
+			"wait":         wait.String(),
+			"error":        err.Error(),
+		})
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		if delay *= 2; policy.MaxBackoff > 0 && delay > policy.MaxBackoff {
+			delay = policy.MaxBackoff
+		}
+	}
+}
+
+func jitter(delay time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 || delay <= 0 {
+		return delay
+	}
+	spread := float64(delay) * fraction
+	return time.Duration(float64(delay) - spread + rand.Float64()*2*spread)
+}
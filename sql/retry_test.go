@@ -0,0 +1,96 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+
+	mssql "github.com/microsoft/go-mssqldb"
+)
+
+func TestExecContext_RetriesTransientErrors(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	fake.addError(mssql.Error{Number: 1205, Message: "deadlock"})
+
+	if err := connector.ExecContext(context.Background(), "SELECT 1"); err != nil {
+		t.Fatal(err)
+	}
+	fake.expectStatements(t,
+		fakeStatement{query: "SELECT 1", args: map[string]interface{}{}},
+		fakeStatement{query: "SELECT 1", args: map[string]interface{}{}},
+	)
+}
+
+func TestExecContext_FailsOnOtherErrors(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	fake.addError(mssql.Error{Number: 2627, Message: "violation of primary key constraint"})
+
+	if err := connector.ExecContext(context.Background(), "SELECT 1"); err == nil {
+		t.Fatal("expected an error")
+	}
+	fake.expectStatements(t, fakeStatement{query: "SELECT 1", args: map[string]interface{}{}})
+}
+
+func TestExecContext_WithoutRetry(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	fake.addError(mssql.Error{Number: 1205, Message: "deadlock"})
+
+	if err := connector.ExecContext(withoutRetry(context.Background()), "SELECT 1"); err == nil {
+		t.Fatal("expected an error")
+	}
+	fake.expectStatements(t, fakeStatement{query: "SELECT 1", args: map[string]interface{}{}})
+}
+
+func TestExecContext_DoesNotRetryNetworkErrors(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	fake.addError(io.EOF)
+
+	if err := connector.ExecContext(context.Background(), "SELECT 1"); err == nil {
+		t.Fatal("expected an error")
+	}
+	fake.expectStatements(t, fakeStatement{query: "SELECT 1", args: map[string]interface{}{}})
+}
+
+func TestExecContext_DoesNotRetryServiceErrors(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	fake.addError(mssql.Error{Number: 40197, Message: "service error processing the request"})
+
+	if err := connector.ExecContext(context.Background(), "SELECT 1"); err == nil {
+		t.Fatal("expected an error")
+	}
+	fake.expectStatements(t, fakeStatement{query: "SELECT 1", args: map[string]interface{}{}})
+}
+
+func TestExecContext_WithRetryWrites(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	fake.addError(io.EOF)
+
+	if err := connector.ExecContext(withRetryWrites(context.Background()), "SELECT 1"); err != nil {
+		t.Fatal(err)
+	}
+	fake.expectStatements(t,
+		fakeStatement{query: "SELECT 1", args: map[string]interface{}{}},
+		fakeStatement{query: "SELECT 1", args: map[string]interface{}{}},
+	)
+}
+
+func TestQueryContext_RetriesNetworkErrors(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	fake.addError(io.EOF)
+	fake.addRows([]string{"n"}, []driver.Value{int64(1)})
+
+	err := connector.QueryContext(context.Background(), "SELECT 1", func(r *sql.Rows) error {
+		for r.Next() {
+		}
+		return r.Err()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.expectStatements(t,
+		fakeStatement{query: "SELECT 1", args: map[string]interface{}{}},
+		fakeStatement{query: "SELECT 1", args: map[string]interface{}{}},
+	)
+}
@@ -0,0 +1,174 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+)
+
+// serverPermissionName matches the names of server permissions, e.g.
+// VIEW SERVER STATE, which are spliced into the statements unquoted.
+var serverPermissionName = regexp.MustCompile(`^[A-Za-z]+( [A-Za-z]+)*$`)
+
+// GetServerPermissions returns the server-level permissions granted to and
+// denied to principalName, or nil if there is no such login or server role.
+// CONNECT SQL, which every login is granted when it is created, is left out.
+func (c *Connector) GetServerPermissions(ctx context.Context, principalName string) (*model.ServerPermissions, error) {
+	cmd := `SELECT pr.principal_id, pr.name, pe.permission_name, pe.state
+			FROM [master].[sys].[server_principals] pr
+			LEFT JOIN [master].[sys].[server_permissions] pe
+				ON pe.grantee_principal_id = pr.principal_id
+				AND pe.class = 100
+				AND pe.permission_name <> 'CONNECT SQL'
+			WHERE pr.name = @principalName`
+	var permissions *model.ServerPermissions
+	err := c.QueryContext(ctx, cmd,
+		func(r *sql.Rows) error {
+			for r.Next() {
+				var (
+					principalId           int
+					name                  string
+					permissionName, state sql.NullString
+				)
+				if err := r.Scan(&principalId, &name, &permissionName, &state); err != nil {
+					return err
+				}
+				if permissions == nil {
+					permissions = &model.ServerPermissions{
+						PrincipalName:     name,
+						PrincipalID:       principalId,
+						Permissions:       make([]string, 0),
+						DeniedPermissions: make([]string, 0),
+					}
+				}
+				if !permissionName.Valid {
+					continue
+				}
+				switch state.String {
+				case "D":
+					permissions.DeniedPermissions = append(permissions.DeniedPermissions, permissionName.String)
+				case "G", "W":
+					permissions.Permissions = append(permissions.Permissions, permissionName.String)
+				}
+			}
+			return nil
+		},
+		sql.Named("principalName", principalName),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+func (c *Connector) CreateServerPermissions(ctx context.Context, permissions *model.ServerPermissions) error {
+	return c.UpdateServerPermissions(ctx, permissions)
+}
+
+// UpdateServerPermissions grants and denies the permissions of the model that
+// are not in that state yet, and revokes the other server-level permissions
+// of the principal.
+func (c *Connector) UpdateServerPermissions(ctx context.Context, permissions *model.ServerPermissions) error {
+	granted, err := serverPermissionSet(permissions.Permissions)
+	if err != nil {
+		return err
+	}
+	denied, err := serverPermissionSet(permissions.DeniedPermissions)
+	if err != nil {
+		return err
+	}
+	for permission := range granted {
+		if denied[permission] {
+			return fmt.Errorf("permission %s can not be both granted and denied", permission)
+		}
+	}
+
+	current, err := c.GetServerPermissions(ctx, permissions.PrincipalName)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return fmt.Errorf("login or server role %s does not exist", permissions.PrincipalName)
+	}
+
+	for _, permission := range current.Permissions {
+		if granted[permission] {
+			delete(granted, permission)
+		} else if !denied[permission] {
+			if err := c.setServerPermission(ctx, "REVOKE", permission, permissions.PrincipalName); err != nil {
+				return err
+			}
+		}
+	}
+	for _, permission := range current.DeniedPermissions {
+		if denied[permission] {
+			delete(denied, permission)
+		} else if !granted[permission] {
+			if err := c.setServerPermission(ctx, "REVOKE", permission, permissions.PrincipalName); err != nil {
+				return err
+			}
+		}
+	}
+	for _, permission := range permissions.Permissions {
+		if !granted[strings.ToUpper(permission)] {
+			continue
+		}
+		delete(granted, strings.ToUpper(permission))
+		if err := c.setServerPermission(ctx, "GRANT", permission, permissions.PrincipalName); err != nil {
+			return err
+		}
+	}
+	for _, permission := range permissions.DeniedPermissions {
+		if !denied[strings.ToUpper(permission)] {
+			continue
+		}
+		delete(denied, strings.ToUpper(permission))
+		if err := c.setServerPermission(ctx, "DENY", permission, permissions.PrincipalName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteServerPermissions revokes the granted and denied permissions of the
+// model from the principal.
+func (c *Connector) DeleteServerPermissions(ctx context.Context, permissions *model.ServerPermissions) error {
+	all := append(append([]string{}, permissions.Permissions...), permissions.DeniedPermissions...)
+	if _, err := serverPermissionSet(all); err != nil {
+		return err
+	}
+	for _, permission := range all {
+		if err := c.setServerPermission(ctx, "REVOKE", permission, permissions.PrincipalName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Connector) setServerPermission(ctx context.Context, action, permission, principalName string) error {
+	preposition := "TO"
+	if action == "REVOKE" {
+		preposition = "FROM"
+	}
+	cmd := `DECLARE @sql nvarchar(max)
+			SET @sql = '` + action + ` ` + strings.ToUpper(permission) + ` ` + preposition + ` ' + QuoteName(@principalName)
+			EXEC (@sql)`
+	return c.ExecContext(ctx, cmd, sql.Named("principalName", principalName))
+}
+
+// serverPermissionSet returns the upper-cased permissions as a set, or an
+// error if one of them is not a valid permission name.
+func serverPermissionSet(permissions []string) (map[string]bool, error) {
+	set := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		if !serverPermissionName.MatchString(permission) {
+			return nil, fmt.Errorf("invalid server permission %q", permission)
+		}
+		set[strings.ToUpper(permission)] = true
+	}
+	return set, nil
+}
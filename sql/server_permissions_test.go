@@ -0,0 +1,150 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+)
+
+func TestGetServerPermissions(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	fake.addRows(
+		[]string{"principal_id", "name", "permission_name", "state"},
+		[]driver.Value{int64(267), "monitor", "VIEW SERVER STATE", "G"},
+		[]driver.Value{int64(267), "monitor", "VIEW ANY DEFINITION", "W"},
+		[]driver.Value{int64(267), "monitor", "ALTER ANY LOGIN", "D"},
+	)
+
+	permissions, err := connector.GetServerPermissions(context.Background(), "monitor")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &model.ServerPermissions{
+		PrincipalName:     "monitor",
+		PrincipalID:       267,
+		Permissions:       []string{"VIEW SERVER STATE", "VIEW ANY DEFINITION"},
+		DeniedPermissions: []string{"ALTER ANY LOGIN"},
+	}
+	if !reflect.DeepEqual(permissions, expected) {
+		t.Errorf("expected %+v, got %+v", expected, permissions)
+	}
+	fake.expectStatements(t, fakeStatement{
+		query: "LEFT JOIN [master].[sys].[server_permissions] pe",
+		args:  map[string]interface{}{"principalName": "monitor"},
+	})
+}
+
+func TestGetServerPermissions_NoPermissions(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	fake.addRows(
+		[]string{"principal_id", "name", "permission_name", "state"},
+		[]driver.Value{int64(267), "monitor", nil, nil},
+	)
+
+	permissions, err := connector.GetServerPermissions(context.Background(), "monitor")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &model.ServerPermissions{
+		PrincipalName:     "monitor",
+		PrincipalID:       267,
+		Permissions:       []string{},
+		DeniedPermissions: []string{},
+	}
+	if !reflect.DeepEqual(permissions, expected) {
+		t.Errorf("expected %+v, got %+v", expected, permissions)
+	}
+}
+
+func TestGetServerPermissions_NotFound(t *testing.T) {
+	connector, _ := newFakeConnector(t)
+
+	permissions, err := connector.GetServerPermissions(context.Background(), "missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if permissions != nil {
+		t.Errorf("expected no permissions, got %+v", permissions)
+	}
+}
+
+func TestUpdateServerPermissions(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	fake.addRows(
+		[]string{"principal_id", "name", "permission_name", "state"},
+		[]driver.Value{int64(267), "monitor", "VIEW SERVER STATE", "G"},
+		[]driver.Value{int64(267), "monitor", "ALTER ANY LOGIN", "G"},
+		[]driver.Value{int64(267), "monitor", "SHUTDOWN", "D"},
+	)
+
+	err := connector.UpdateServerPermissions(context.Background(), &model.ServerPermissions{
+		PrincipalName:     "monitor",
+		Permissions:       []string{"view server state", "ALTER ANY EVENT SESSION"},
+		DeniedPermissions: []string{"ALTER ANY LOGIN"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake.expectStatements(t,
+		fakeStatement{
+			query: "WHERE pr.name = @principalName",
+			args:  map[string]interface{}{"principalName": "monitor"},
+		},
+		fakeStatement{
+			query: "'REVOKE SHUTDOWN FROM ' + QuoteName(@principalName)",
+			args:  map[string]interface{}{"principalName": "monitor"},
+		},
+		fakeStatement{
+			query: "'GRANT ALTER ANY EVENT SESSION TO ' + QuoteName(@principalName)",
+			args:  map[string]interface{}{"principalName": "monitor"},
+		},
+		fakeStatement{
+			query: "'DENY ALTER ANY LOGIN TO ' + QuoteName(@principalName)",
+			args:  map[string]interface{}{"principalName": "monitor"},
+		},
+	)
+}
+
+func TestUpdateServerPermissions_Invalid(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+
+	for _, permissions := range []*model.ServerPermissions{
+		{PrincipalName: "monitor", Permissions: []string{"VIEW SERVER STATE TO [x]; DROP LOGIN [sa] --"}},
+		{PrincipalName: "monitor", Permissions: []string{"SHUTDOWN"}, DeniedPermissions: []string{"shutdown"}},
+	} {
+		if err := connector.UpdateServerPermissions(context.Background(), permissions); err == nil {
+			t.Errorf("expected an error for %+v", permissions)
+		}
+	}
+	fake.expectStatements(t)
+}
+
+func TestDeleteServerPermissions(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+
+	err := connector.DeleteServerPermissions(context.Background(), &model.ServerPermissions{
+		PrincipalName:     "monitor",
+		Permissions:       []string{"VIEW SERVER STATE"},
+		DeniedPermissions: []string{"SHUTDOWN"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake.expectStatements(t,
+		fakeStatement{
+			query: "'REVOKE VIEW SERVER STATE FROM ' + QuoteName(@principalName)",
+			args:  map[string]interface{}{"principalName": "monitor"},
+		},
+		fakeStatement{
+			query: "'REVOKE SHUTDOWN FROM ' + QuoteName(@principalName)",
+			args:  map[string]interface{}{"principalName": "monitor"},
+		},
+	)
+}
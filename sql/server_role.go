@@ -0,0 +1,90 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+)
+
+func (c *Connector) GetServerRole(ctx context.Context, roleName string) (*model.ServerRole, error) {
+	cmd := `SELECT
+				sp2.principal_id,
+				sp2.name,
+				sp2.owning_principal_id,
+				sp1.name AS ownerName
+			FROM [master].[sys].[server_principals] sp1
+			INNER JOIN [master].[sys].[server_principals] sp2
+				ON sp1.principal_id = sp2.owning_principal_id
+			WHERE sp2.type = 'R'
+				AND sp2.name = @roleName`
+	var role model.ServerRole
+	err := c.QueryRowContext(ctx, cmd,
+		func(r *sql.Row) error {
+			return r.Scan(&role.RoleID, &role.RoleName, &role.OwnerId, &role.OwnerName)
+		},
+		sql.Named("roleName", roleName),
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (c *Connector) CreateServerRole(ctx context.Context, roleName string, ownerName string) error {
+	cmd := `DECLARE @sql nvarchar(max);
+			IF @ownerName = ''
+				BEGIN
+					SET @sql = 'CREATE SERVER ROLE ' + QuoteName(@roleName)
+				END
+			ELSE
+				BEGIN
+					SET @sql = 'CREATE SERVER ROLE ' + QuoteName(@roleName) + ' AUTHORIZATION ' + QuoteName(@ownerName)
+				END
+			EXEC (@sql);`
+
+	return c.
+		ExecContext(ctx, cmd,
+			sql.Named("roleName", roleName),
+			sql.Named("ownerName", ownerName),
+		)
+}
+
+func (c *Connector) DeleteServerRole(ctx context.Context, roleName string) error {
+	cmd := `DECLARE @sql nvarchar(max)
+			SET @sql = 'IF EXISTS (SELECT 1 FROM [master].[sys].[server_principals] WHERE [type] = ''R'' AND [name] = ' + QuoteName(@roleName, '''') + ') ' +
+						'DROP SERVER ROLE ' + QuoteName(@roleName)
+			EXEC (@sql)`
+
+	return c.
+		ExecContext(ctx, cmd,
+			sql.Named("roleName", roleName),
+		)
+}
+
+// UpdateServerRole renames the server role roleId to roleName, and transfers
+// it to ownerName unless it is empty.
+func (c *Connector) UpdateServerRole(ctx context.Context, roleId int, roleName string, ownerName string) error {
+	cmd := `DECLARE @sql nvarchar(max) = ''
+			DECLARE @old_role_name nvarchar(max) = (SELECT name FROM [master].[sys].[server_principals] WHERE [type] = 'R' AND [principal_id] = @principalId)
+			DECLARE @old_owner_name nvarchar(max) = (SELECT sp1.name FROM [master].[sys].[server_principals] sp1 INNER JOIN [master].[sys].[server_principals] sp2 ON sp1.principal_id = sp2.owning_principal_id AND sp2.principal_id = @principalId)
+			IF @old_role_name != @roleName
+				BEGIN
+					SET @sql = 'ALTER SERVER ROLE ' + QuoteName(@old_role_name) + ' WITH NAME = ' + QuoteName(@roleName) + ';'
+				END
+			IF @ownerName != '' AND @old_owner_name != @ownerName
+				BEGIN
+					SET @sql = @sql + 'ALTER AUTHORIZATION ON SERVER ROLE::' + QuoteName(@roleName) + ' TO ' + QuoteName(@ownerName) + ';'
+				END
+			EXEC (@sql)`
+
+	return c.
+		ExecContext(ctx, cmd,
+			sql.Named("roleName", roleName),
+			sql.Named("principalId", roleId),
+			sql.Named("ownerName", ownerName),
+		)
+}
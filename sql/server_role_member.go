@@ -0,0 +1,114 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+)
+
+func (c *Connector) GetServerRoleMember(ctx context.Context, roleName, memberName string) (*model.ServerRoleMember, error) {
+	cmd := `SELECT r.principal_id, r.name, m.principal_id, m.name
+			FROM [master].[sys].[server_role_members] srm
+			INNER JOIN [master].[sys].[server_principals] r ON r.principal_id = srm.role_principal_id
+			INNER JOIN [master].[sys].[server_principals] m ON m.principal_id = srm.member_principal_id
+			WHERE r.name = @roleName
+				AND m.name = @memberName`
+	var member model.ServerRoleMember
+	err := c.QueryRowContext(ctx, cmd,
+		func(r *sql.Row) error {
+			return r.Scan(&member.RoleID, &member.RoleName, &member.MemberID, &member.MemberName)
+		},
+		sql.Named("roleName", roleName),
+		sql.Named("memberName", memberName),
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (c *Connector) AddServerRoleMember(ctx context.Context, roleName, memberName string) error {
+	cmd := `DECLARE @sql nvarchar(max)
+			SET @sql = 'ALTER SERVER ROLE ' + QuoteName(@roleName) + ' ADD MEMBER ' + QuoteName(@memberName)
+			EXEC (@sql)`
+	return c.
+		ExecContext(ctx, cmd,
+			sql.Named("roleName", roleName),
+			sql.Named("memberName", memberName),
+		)
+}
+
+func (c *Connector) DropServerRoleMember(ctx context.Context, roleName, memberName string) error {
+	cmd := `DECLARE @sql nvarchar(max)
+			SET @sql = 'IF IS_SRVROLEMEMBER(' + QuoteName(@roleName, '''') + ', ' + QuoteName(@memberName, '''') + ') = 1 ' +
+						'ALTER SERVER ROLE ' + QuoteName(@roleName) + ' DROP MEMBER ' + QuoteName(@memberName)
+			EXEC (@sql)`
+	return c.
+		ExecContext(ctx, cmd,
+			sql.Named("roleName", roleName),
+			sql.Named("memberName", memberName),
+		)
+}
+
+// getServerRoles returns the names of the server roles memberName is a direct
+// member of, in order.
+func (c *Connector) getServerRoles(ctx context.Context, memberName string) ([]string, error) {
+	cmd := `SELECT r.name
+			FROM [master].[sys].[server_role_members] srm
+			INNER JOIN [master].[sys].[server_principals] r ON r.principal_id = srm.role_principal_id
+			INNER JOIN [master].[sys].[server_principals] m ON m.principal_id = srm.member_principal_id
+			WHERE m.name = @memberName
+			ORDER BY r.name`
+	roles := make([]string, 0)
+	err := c.QueryContext(ctx, cmd,
+		func(r *sql.Rows) error {
+			for r.Next() {
+				var role string
+				if err := r.Scan(&role); err != nil {
+					return err
+				}
+				roles = append(roles, role)
+			}
+			return nil
+		},
+		sql.Named("memberName", memberName),
+	)
+	return roles, err
+}
+
+// UpdateServerRoles adds memberName to the server roles in roles it is not a
+// member of yet, and drops it from the others.
+func (c *Connector) UpdateServerRoles(ctx context.Context, memberName string, roles []string) error {
+	current, err := c.getServerRoles(ctx, memberName)
+	if err != nil {
+		return err
+	}
+	wanted := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		wanted[strings.ToLower(role)] = true
+	}
+	for _, role := range current {
+		if wanted[strings.ToLower(role)] {
+			delete(wanted, strings.ToLower(role))
+			continue
+		}
+		if err := c.DropServerRoleMember(ctx, role, memberName); err != nil {
+			return err
+		}
+	}
+	for _, role := range roles {
+		if !wanted[strings.ToLower(role)] {
+			continue
+		}
+		delete(wanted, strings.ToLower(role))
+		if err := c.AddServerRoleMember(ctx, role, memberName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
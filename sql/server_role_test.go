@@ -0,0 +1,88 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+)
+
+func TestCreateServerRole(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+
+	if err := connector.CreateServerRole(context.Background(), "auditors", "security_admin"); err != nil {
+		t.Fatal(err)
+	}
+
+	fake.expectStatements(t, fakeStatement{
+		query: "'CREATE SERVER ROLE ' + QuoteName(@roleName) + ' AUTHORIZATION ' + QuoteName(@ownerName)",
+		args: map[string]interface{}{
+			"roleName":  "auditors",
+			"ownerName": "security_admin",
+		},
+	})
+}
+
+func TestUpdateServerRole(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+
+	if err := connector.UpdateServerRole(context.Background(), 267, "auditors", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	fake.expectStatements(t, fakeStatement{
+		query: "'ALTER SERVER ROLE ' + QuoteName(@old_role_name) + ' WITH NAME = ' + QuoteName(@roleName)",
+		args: map[string]interface{}{
+			"roleName":    "auditors",
+			"principalId": 267,
+			"ownerName":   "",
+		},
+	})
+}
+
+func TestGetServerRole(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	fake.addRows([]string{"principal_id", "name", "owning_principal_id", "ownerName"},
+		[]driver.Value{int64(267), "auditors", int64(1), "sa"},
+	)
+
+	role, err := connector.GetServerRole(context.Background(), "auditors")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &model.ServerRole{RoleID: 267, RoleName: "auditors", OwnerId: 1, OwnerName: "sa"}
+	if !reflect.DeepEqual(role, expected) {
+		t.Errorf("expected %+v, got %+v", expected, role)
+	}
+
+	fake.expectStatements(t, fakeStatement{
+		query: "FROM [master].[sys].[server_principals] sp1",
+		args:  map[string]interface{}{"roleName": "auditors"},
+	})
+}
+
+func TestUpdateServerRoles(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	fake.addRows([]string{"name"}, []driver.Value{"auditors"}, []driver.Value{"dbcreator"})
+
+	if err := connector.UpdateServerRoles(context.Background(), "login", []string{"DBCreator", "securityadmin"}); err != nil {
+		t.Fatal(err)
+	}
+
+	fake.expectStatements(t,
+		fakeStatement{
+			query: "WHERE m.name = @memberName",
+			args:  map[string]interface{}{"memberName": "login"},
+		},
+		fakeStatement{
+			query: "' DROP MEMBER ' + QuoteName(@memberName)",
+			args:  map[string]interface{}{"roleName": "auditors", "memberName": "login"},
+		},
+		fakeStatement{
+			query: "' ADD MEMBER ' + QuoteName(@memberName)",
+			args:  map[string]interface{}{"roleName": "securityadmin", "memberName": "login"},
+		},
+	)
+}
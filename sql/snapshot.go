@@ -0,0 +1,204 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+)
+
+// snapshotCache holds the principals, role memberships and permissions of
+// each database, read with a few queries on the first refresh of a resource
+// in the database and served to the refresh of all the others. Every
+// statement executed on a server drops the snapshots of its databases.
+type snapshotCache struct {
+	mu      sync.Mutex
+	entries map[snapshotKey]*snapshotEntry
+}
+
+type snapshotKey struct {
+	host     string
+	port     string
+	instance string
+	database string
+}
+
+type snapshotEntry struct {
+	mu       sync.Mutex
+	snapshot *databaseSnapshot
+}
+
+// databaseSnapshot indexes the principals of one database by name.
+type databaseSnapshot struct {
+	principals map[string]*snapshotPrincipal
+}
+
+type snapshotPrincipal struct {
+	id              int64
+	name            string
+	typ             string
+	authType        string
+	defaultSchema   string
+	defaultLanguage string
+	sid             []byte
+	sidStr          string
+	loginName       string
+	// roles are the names of the roles the principal is a direct member of.
+	roles []string
+	// permissions are the distinct database permissions granted to the
+	// principal, except CONNECT.
+	permissions []string
+}
+
+func newSnapshotCache() *snapshotCache {
+	return &snapshotCache{entries: make(map[snapshotKey]*snapshotEntry)}
+}
+
+func (s *snapshotCache) entry(key snapshotKey) *snapshotEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		e = &snapshotEntry{}
+		s.entries[key] = e
+	}
+	return e
+}
+
+// invalidate drops the snapshots of all databases of the server of c, as a
+// statement may change logins in master as well as its own database. A nil
+// cache does nothing.
+func (s *snapshotCache) invalidate(c *Connector) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key := range s.entries {
+		if key.host == c.Host && key.port == c.Port && key.instance == c.Instance {
+			delete(s.entries, key)
+		}
+	}
+}
+
+// snapshot returns the snapshot of database, loading it on first use, when
+// the statement is run for the refresh of a resource or data source. It
+// returns nil for creates, updates, deletes and imports, which must see the
+// current state of the server, and when bulk reads are disabled.
+func (c *Connector) snapshot(ctx context.Context, database string) (*databaseSnapshot, error) {
+	if c.snapshots == nil {
+		return nil, nil
+	}
+	if op, ok := model.OperationFromContext(ctx); !ok || op.Action != "read" {
+		return nil, nil
+	}
+	e := c.snapshots.entry(snapshotKey{host: c.Host, port: c.Port, instance: c.Instance, database: database})
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.snapshot != nil {
+		return e.snapshot, nil
+	}
+	snapshot, err := c.loadSnapshot(ctx, database)
+	if err != nil {
+		return nil, err
+	}
+	e.snapshot = snapshot
+	return snapshot, nil
+}
+
+// loadSnapshot reads the principals of database with the names of their
+// server logins, the roles they are direct members of and their permissions.
+// It runs on a copy of c, so refreshes sharing c keep their database.
+func (c *Connector) loadSnapshot(ctx context.Context, database string) (*databaseSnapshot, error) {
+	connector := *c
+	c = connector.setDatabase(&database)
+	caps, err := c.GetServerCapabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &databaseSnapshot{principals: make(map[string]*snapshotPrincipal)}
+	byID := make(map[int64]*snapshotPrincipal)
+
+	cmd := `DECLARE @stmt nvarchar(max)
+			IF @azure = 1
+				SET @stmt = 'SELECT p.principal_id, p.name, p.type, p.authentication_type_desc, COALESCE(p.default_schema_name, ''''), COALESCE(p.default_language_name, ''''), p.sid, COALESCE(CONVERT(VARCHAR(85), p.sid, 1), ''''), '''' ' +
+							'FROM [sys].[database_principals] p'
+			ELSE
+				SET @stmt = 'SELECT p.principal_id, p.name, p.type, p.authentication_type_desc, COALESCE(p.default_schema_name, ''''), COALESCE(p.default_language_name, ''''), p.sid, COALESCE(CONVERT(VARCHAR(85), p.sid, 1), ''''), COALESCE(sl.name, '''') ' +
+							'FROM [sys].[database_principals] p' +
+							'  LEFT JOIN [master].[sys].[sql_logins] sl ON p.sid = sl.sid'
+			EXEC (@stmt)`
+	err = c.QueryContext(ctx, cmd,
+		func(r *sql.Rows) error {
+			for r.Next() {
+				var p snapshotPrincipal
+				if err := r.Scan(&p.id, &p.name, &p.typ, &p.authType, &p.defaultSchema, &p.defaultLanguage, &p.sid, &p.sidStr, &p.loginName); err != nil {
+					return err
+				}
+				p.roles, p.permissions = make([]string, 0), make([]string, 0)
+				snapshot.principals[p.name] = &p
+				byID[p.id] = &p
+			}
+			return nil
+		},
+		sql.Named("azure", caps.IsAzure()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd = "SELECT member_principal_id, role_principal_id FROM [sys].[database_role_members]"
+	err = c.QueryContext(ctx, cmd,
+		func(r *sql.Rows) error {
+			for r.Next() {
+				var memberID, roleID int64
+				if err := r.Scan(&memberID, &roleID); err != nil {
+					return err
+				}
+				member, role := byID[memberID], byID[roleID]
+				if member != nil && role != nil {
+					member.roles = append(member.roles, role.name)
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd = "SELECT DISTINCT grantee_principal_id, permission_name FROM [sys].[database_permissions] WHERE permission_name <> 'CONNECT'"
+	err = c.QueryContext(ctx, cmd,
+		func(r *sql.Rows) error {
+			for r.Next() {
+				var granteeID int64
+				var permission string
+				if err := r.Scan(&granteeID, &permission); err != nil {
+					return err
+				}
+				if grantee := byID[granteeID]; grantee != nil {
+					grantee.permissions = append(grantee.permissions, permission)
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// principal returns the principal named name. A nil snapshot has none.
+func (s *databaseSnapshot) principal(name string) (*snapshotPrincipal, bool) {
+	if s == nil {
+		return nil, false
+	}
+	p, ok := s.principals[name]
+	return p, ok
+}
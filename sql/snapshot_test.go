@@ -0,0 +1,152 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+)
+
+func newSnapshotConnector(t *testing.T) (*Connector, *fakeServer, context.Context) {
+	connector, fake := newFakeConnector(t)
+	connector.snapshots = newSnapshotCache()
+	ctx := model.WithOperation(context.Background(), model.Operation{Resource: "mssql_user", Action: "read"})
+	return connector, fake, ctx
+}
+
+func addSnapshot(fake *fakeServer) {
+	fake.addRows(
+		[]string{"principal_id", "name", "type", "authentication_type_desc", "default_schema_name", "default_language_name", "sid", "sidStr", "login_name"},
+		[]driver.Value{int64(5), "user", "S", "INSTANCE", "dbo", "", []byte{0x01}, "0x01", "login"},
+		[]driver.Value{int64(16384), "db_datareader", "R", "NONE", "", "", []byte{0x02}, "0x02", ""},
+	)
+	fake.addRows([]string{"member_principal_id", "role_principal_id"},
+		[]driver.Value{int64(5), int64(16384)},
+	)
+	fake.addRows([]string{"grantee_principal_id", "permission_name"},
+		[]driver.Value{int64(5), "SELECT"},
+		[]driver.Value{int64(5), "EXECUTE"},
+	)
+}
+
+var snapshotStatements = []fakeStatement{
+	{query: "LEFT JOIN [master].[sys].[sql_logins] sl ON p.sid = sl.sid", args: map[string]interface{}{"azure": false}},
+	{query: "FROM [sys].[database_role_members]", args: map[string]interface{}{}},
+	{query: "FROM [sys].[database_permissions]", args: map[string]interface{}{}},
+}
+
+func TestSnapshot_ServesRefreshes(t *testing.T) {
+	connector, fake, ctx := newSnapshotConnector(t)
+	addSnapshot(fake)
+
+	user, err := connector.GetUser(ctx, "db", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &model.User{
+		PrincipalID:   5,
+		Username:      "user",
+		TypeStr:       "S",
+		AuthType:      "INSTANCE",
+		DefaultSchema: "dbo",
+		SIDStr:        "0x01",
+		LoginName:     "login",
+		Roles:         []string{"db_datareader"},
+	}
+	if !reflect.DeepEqual(user, expected) {
+		t.Errorf("expected %+v, got %+v", expected, user)
+	}
+
+	permissions, err := connector.GetDatabasePermissions(ctx, "db", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"SELECT", "EXECUTE"}; !reflect.DeepEqual(permissions.Permissions, expected) {
+		t.Errorf("expected permissions %v, got %v", expected, permissions.Permissions)
+	}
+
+	fake.expectStatements(t, snapshotStatements...)
+}
+
+func TestSnapshot_InvalidatedByWrites(t *testing.T) {
+	connector, fake, ctx := newSnapshotConnector(t)
+	addSnapshot(fake)
+
+	if _, err := connector.GetUser(ctx, "db", "user"); err != nil {
+		t.Fatal(err)
+	}
+	if err := connector.ExecContext(ctx, "DROP USER [user]"); err != nil {
+		t.Fatal(err)
+	}
+	fake.addRows([]string{"principal_id"})
+	fake.addRows([]string{"member_principal_id", "role_principal_id"})
+	fake.addRows([]string{"grantee_principal_id", "permission_name"})
+	fake.addRows([]string{"principal_id"})
+
+	user, err := connector.GetUser(ctx, "db", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user != nil {
+		t.Errorf("expected no user, got %+v", user)
+	}
+
+	expected := append([]fakeStatement{}, snapshotStatements...)
+	expected = append(expected, fakeStatement{query: "DROP USER [user]", args: map[string]interface{}{}})
+	expected = append(expected, snapshotStatements...)
+	// Users missing from the snapshot are queried directly.
+	expected = append(expected, fakeStatement{query: "[sys].[database_principals] p", args: map[string]interface{}{"database": "db", "username": "user", "azure": false}})
+	fake.expectStatements(t, expected...)
+}
+
+func TestSnapshot_NotUsedOutsideRefresh(t *testing.T) {
+	connector, fake, _ := newSnapshotConnector(t)
+	ctx := model.WithOperation(context.Background(), model.Operation{Resource: "mssql_user", Action: "create"})
+
+	if _, err := connector.GetUser(ctx, "db", "user"); err != nil {
+		t.Fatal(err)
+	}
+
+	fake.expectStatements(t, fakeStatement{
+		query: "[sys].[database_principals] p",
+		args:  map[string]interface{}{"database": "db", "username": "user", "azure": false},
+	})
+}
+
+func TestSnapshot_MatchesQuery(t *testing.T) {
+	connector, fake, ctx := newSnapshotConnector(t)
+	addSnapshot(fake)
+
+	fromSnapshot, err := connector.GetUser(ctx, "db", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if connector.Database != "" {
+		t.Errorf("expected the snapshot to leave the database of the connector alone, got %q", connector.Database)
+	}
+
+	// The query returns the same columns for the user, with its direct roles
+	// aggregated.
+	fake.addRows(
+		[]string{"principal_id", "name", "type", "authentication_type_desc", "default_schema_name", "default_language_name", "sid", "sidStr", "login_name", "roles"},
+		[]driver.Value{int64(5), "user", "S", "INSTANCE", "dbo", "", []byte{0x01}, "0x01", "login", "db_datareader"},
+	)
+	ctx = model.WithOperation(context.Background(), model.Operation{Resource: "mssql_user", Action: "create"})
+	fromQuery, err := connector.GetUser(ctx, "db", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromSnapshot, fromQuery) {
+		t.Errorf("expected %+v from the query, got %+v", fromSnapshot, fromQuery)
+	}
+
+	expected := append([]fakeStatement{}, snapshotStatements...)
+	// Both read the direct role memberships only.
+	expected = append(expected, fakeStatement{
+		query: "LEFT JOIN ' + QuoteName(@database) + '.[sys].[database_role_members] r ON p.principal_id = r.member_principal_id",
+		args:  map[string]interface{}{"database": "db", "username": "user", "azure": false},
+	})
+	fake.expectStatements(t, expected...)
+}
@@ -5,73 +5,248 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
-	"log"
+	"io"
 	"net/url"
-	"strings"
+	"os"
+	"strconv"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	mssql "github.com/microsoft/go-mssqldb"
 	"github.com/microsoft/go-mssqldb/azuread"
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
 )
 
-type factory struct{}
+// DefaultAppName identifies the sessions of the provider, e.g. in
+// sys.dm_exec_sessions, when the server block has no app_name.
+const DefaultAppName = "terraform-provider-mssql"
+
+// ErrReadOnly is returned for every statement executed by a read-only
+// connector, which covers all the changes made by resources.
+var ErrReadOnly = errors.New("the provider is read-only (read_only = true), refusing to change the server")
+
+// planTimeout is the connection timeout of connectors created at plan time,
+// when resource timeouts are not available.
+const planTimeout = 30 * time.Second
+
+type factory struct {
+	config    *model.ProviderConfig
+	pool      *pool
+	limiter   *writeLimiter
+	snapshots *snapshotCache
+}
 
 func GetFactory() model.ConnectorFactory {
-	return new(factory)
+	return &factory{config: &model.ProviderConfig{}}
+}
+
+func (f factory) Configure(config *model.ProviderConfig) model.ConnectorFactory {
+	var snapshots *snapshotCache
+	if config.BulkRead {
+		snapshots = newSnapshotCache()
+	}
+	return &factory{
+		config:    config,
+		pool:      newPool(config.MaxOpenConns, config.MaxIdleConns),
+		limiter:   newWriteLimiter(config.MaxDatabaseWrites),
+		snapshots: snapshots,
+	}
+}
+
+// Close closes all pooled connections opened through this factory.
+func (f factory) Close() error {
+	if f.pool == nil {
+		return nil
+	}
+	return f.pool.close()
+}
+
+// GetServer returns the server block for a resource. An inline block takes
+// precedence, then the provider profile named by <prefix>_ref, and finally
+// the provider default server.
+func (f factory) GetServer(prefix string, data model.ResourceData) (map[string]interface{}, error) {
+	if server, ok := data.GetOk(prefix + ".0"); ok {
+		return server.(map[string]interface{}), nil
+	}
+	if ref, ok := data.GetOk(prefix + "_ref"); ok {
+		server, ok := f.config.ServerProfiles[ref.(string)]
+		if !ok {
+			return nil, errors.Errorf("server profile [%s] is not configured in the provider", ref)
+		}
+		return server, nil
+	}
+	if f.config.Server != nil {
+		return f.config.Server, nil
+	}
+	return nil, errors.Errorf("no %s block or %s_ref specified, and no default server configured in the provider", prefix, prefix)
 }
 
-func (f factory) GetConnector(prefix string, data *schema.ResourceData) (interface{}, error) {
-	if len(prefix) > 0 {
-		prefix = prefix + ".0."
+func (f factory) GetConnector(prefix string, data model.ResourceData) (interface{}, error) {
+	server, err := f.GetServer(prefix, data)
+	if err != nil {
+		return nil, err
 	}
 
 	connector := &Connector{
-		Host:    data.Get(prefix + "host").(string),
-		Port:    data.Get(prefix + "port").(string),
-		Timeout: data.Timeout(schema.TimeoutRead),
+		Host:             server["host"].(string),
+		Port:             server["port"].(string),
+		Timeout:          planTimeout,
+		Retry:            f.config.Retry,
+		ReadOnly:         f.config.ReadOnly,
+		WriteLock:        f.config.WriteLock,
+		WriteLockTimeout: f.config.WriteLockTimeout,
+		limiter:          f.limiter,
+		snapshots:        f.snapshots,
+		pool:             f.pool,
+		logFile:          f.config.Logger,
+		sqlOutput:        f.config.SQLOutput,
+		auditTable:       f.config.AuditTable,
 	}
 
-	if admin, ok := data.GetOk(prefix + "login.0"); ok {
-		admin := admin.(map[string]interface{})
+	if d, ok := data.(*schema.ResourceData); ok {
+		connector.Timeout = d.Timeout(schema.TimeoutRead)
+	}
+
+	connector.Instance, _ = server["instance"].(string)
+
+	if timeout, _ := server["connect_timeout"].(string); timeout != "" {
+		if connector.Timeout, err = time.ParseDuration(timeout); err != nil {
+			return nil, errors.Wrapf(err, "invalid connect_timeout [%s]", timeout)
+		}
+	}
+
+	connector.Options = ConnectionOptions{
+		AppName:             server["app_name"].(string),
+		ApplicationIntent:   server["application_intent"].(string),
+		MultiSubnetFailover: server["multi_subnet_failover"].(bool),
+		PacketSize:          server["packet_size"].(int),
+	}
+
+	connector.TLS = TLSSettings{
+		Encrypt:                server["encrypt"].(string),
+		TrustServerCertificate: server["trust_server_certificate"].(bool),
+		HostNameInCertificate:  server["host_name_in_certificate"].(string),
+		CACertificatePath:      server["ca_certificate_path"].(string),
+	}
+
+	if admin, ok := getBlock(server, "login"); ok {
 		connector.Login = &LoginUser{
 			Username: admin["username"].(string),
 			Password: admin["password"].(string),
 		}
 	}
 
-	if admin, ok := data.GetOk(prefix + "azure_login.0"); ok {
-		admin := admin.(map[string]interface{})
+	if admin, ok := getBlock(server, "azure_login"); ok {
 		connector.AzureLogin = &AzureLogin{
 			TenantID:     admin["tenant_id"].(string),
 			ClientID:     admin["client_id"].(string),
 			ClientSecret: admin["client_secret"].(string),
 		}
+		connector.AzureLogin.ClientCertificatePath, _ = admin["client_certificate_path"].(string)
+		connector.AzureLogin.ClientCertificatePassword, _ = admin["client_certificate_password"].(string)
+		connector.AzureLogin.ClientSecret, connector.AzureLogin.ClientCertificatePath, err = azureLoginCredential(
+			connector.AzureLogin.ClientSecret, connector.AzureLogin.ClientCertificatePath)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if admin, ok := data.GetOk(prefix + "azuread_managed_identity_auth.0"); ok {
-		admin := admin.(map[string]interface{})
+	if admin, ok := getBlock(server, "azuread_managed_identity_auth"); ok {
+		userId, _ := admin["user_id"].(string)
 		connector.FedauthMSI = &FedauthMSI{
-			UserID: admin["user_id"].(string),
+			UserID: userId,
+		}
+	}
+
+	if admin, ok := getBlock(server, "azuread_access_token_auth"); ok {
+		connector.Token = admin["access_token"].(string)
+	}
+
+	if admin, ok := getBlock(server, "azuread_workload_identity_auth"); ok {
+		tenantId, _ := admin["tenant_id"].(string)
+		clientId, _ := admin["client_id"].(string)
+		tokenFilePath, _ := admin["token_file_path"].(string)
+		connector.WorkloadIdentity = &WorkloadIdentity{
+			TenantID:      tenantId,
+			ClientID:      clientId,
+			TokenFilePath: tokenFilePath,
 		}
 	}
 
 	return connector, nil
 }
 
+// azureLoginCredential returns the client secret and certificate path of an
+// azure_login block, of which exactly one must be set. Both default to their
+// environment variable, so a value equal to it is only used when the other is
+// not configured, e.g. a configured certificate is not combined with an
+// exported MSSQL_CLIENT_SECRET. Checked here as well as in the schema, which
+// does not see the environment.
+func azureLoginCredential(secret, certificatePath string) (string, string, error) {
+	if secret != "" && certificatePath != "" {
+		secretFromEnv := secret == os.Getenv("MSSQL_CLIENT_SECRET")
+		certificateFromEnv := certificatePath == os.Getenv("MSSQL_CLIENT_CERTIFICATE_PATH")
+		if secretFromEnv && !certificateFromEnv {
+			secret = ""
+		} else if certificateFromEnv && !secretFromEnv {
+			certificatePath = ""
+		}
+	}
+	if (secret == "") == (certificatePath == "") {
+		return "", "", errors.New("azure_login requires exactly one of client_secret and client_certificate_path")
+	}
+	return secret, certificatePath, nil
+}
+
+// getBlock returns the attributes of a single nested block, or false if the block is absent.
+// An empty block such as `azuread_default_chain_auth {}` yields an empty map.
+func getBlock(server map[string]interface{}, name string) (map[string]interface{}, bool) {
+	block, ok := server[name].([]interface{})
+	if !ok || len(block) == 0 {
+		return nil, false
+	}
+	if attrs, ok := block[0].(map[string]interface{}); ok {
+		return attrs, true
+	}
+	return map[string]interface{}{}, true
+}
+
 type Connector struct {
-	Host       string `json:"host"`
-	Port       string `json:"port"`
-	Database   string `json:"database"`
-	Login      *LoginUser
-	AzureLogin *AzureLogin
-	FedauthMSI *FedauthMSI
-	Timeout    time.Duration `json:"timeout,omitempty"`
-	Token      string
+	Host             string `json:"host"`
+	Port             string `json:"port"`
+	Instance         string `json:"instance,omitempty"`
+	Database         string `json:"database"`
+	Login            *LoginUser
+	AzureLogin       *AzureLogin
+	FedauthMSI       *FedauthMSI
+	WorkloadIdentity *WorkloadIdentity
+	TLS              TLSSettings
+	Options          ConnectionOptions
+	Retry            model.RetryPolicy
+	Timeout          time.Duration `json:"timeout,omitempty"`
+	Token            string
+	// ReadOnly makes ExecContext fail without sending the statement.
+	ReadOnly bool
+	// WriteLock makes ExecContext hold the application lock of the database
+	// while executing, waiting at most WriteLockTimeout to acquire it.
+	WriteLock        bool
+	WriteLockTimeout time.Duration
+	// Driver, when set, is used instead of connecting to the server with the
+	// settings above, e.g. to run the statements against a fake in tests.
+	Driver  driver.Connector
+	pool    *pool
+	limiter *writeLimiter
+	// snapshots serves the reads of refreshes, see snapshot.
+	snapshots *snapshotCache
+	logFile   *zerolog.Logger
+	// sqlOutput receives the executed statements instead of the server.
+	sqlOutput io.Writer
+	// auditTable records the executed statements of resource operations.
+	auditTable string
 }
 
 type LoginUser struct {
@@ -79,21 +254,51 @@ type LoginUser struct {
 	Password string `json:"password,omitempty"`
 }
 
+// AzureLogin authenticates with a client certificate when ClientCertificatePath
+// is set, and with ClientSecret otherwise.
 type AzureLogin struct {
-	TenantID     string `json:"tenant_id,omitempty"`
-	ClientID     string `json:"client_id,omitempty"`
-	ClientSecret string `json:"client_secret,omitempty"`
+	TenantID                  string `json:"tenant_id,omitempty"`
+	ClientID                  string `json:"client_id,omitempty"`
+	ClientSecret              string `json:"client_secret,omitempty"`
+	ClientCertificatePath     string `json:"client_certificate_path,omitempty"`
+	ClientCertificatePassword string `json:"client_certificate_password,omitempty"`
 }
 
 type FedauthMSI struct {
 	UserID string `json:"user_id,omitempty"`
 }
 
+// WorkloadIdentity settings left empty are read by azidentity from the
+// AZURE_TENANT_ID, AZURE_CLIENT_ID and AZURE_FEDERATED_TOKEN_FILE variables.
+type WorkloadIdentity struct {
+	TenantID      string `json:"tenant_id,omitempty"`
+	ClientID      string `json:"client_id,omitempty"`
+	TokenFilePath string `json:"token_file_path,omitempty"`
+}
+
+// ConnectionOptions are the connection string settings that identify and
+// route the session. Zero values keep the driver defaults, except for the
+// application name.
+type ConnectionOptions struct {
+	AppName             string `json:"app_name,omitempty"`
+	ApplicationIntent   string `json:"application_intent,omitempty"`
+	MultiSubnetFailover bool   `json:"multi_subnet_failover,omitempty"`
+	PacketSize          int    `json:"packet_size,omitempty"`
+}
+
+type TLSSettings struct {
+	Encrypt                string `json:"encrypt,omitempty"`
+	TrustServerCertificate bool   `json:"trust_server_certificate,omitempty"`
+	HostNameInCertificate  string `json:"host_name_in_certificate,omitempty"`
+	CACertificatePath      string `json:"ca_certificate_path,omitempty"`
+}
+
 func (c *Connector) PingContext(ctx context.Context) error {
-	db, err := c.db()
+	db, release, err := c.db(ctx)
 	if err != nil {
 		return err
 	}
+	defer release()
 
 	err = db.PingContext(ctx)
 	if err != nil {
@@ -104,29 +309,81 @@ func (c *Connector) PingContext(ctx context.Context) error {
 }
 
 // Execute an SQL statement and ignore the results
-func (c *Connector) ExecContext(ctx context.Context, command string, args ...interface{}) error {
-	db, err := c.db()
+func (c *Connector) ExecContext(ctx context.Context, command string, args ...interface{}) (err error) {
+	ctx, span := c.traceStatement(ctx, command, args)
+	defer func() { endSpan(span, err) }()
+
+	if c.sqlOutput != nil {
+		return c.emitStatement(ctx, command, args)
+	}
+	if c.ReadOnly {
+		return ErrReadOnly
+	}
+
+	db, release, err := c.db(ctx)
 	if err != nil {
 		return err
 	}
-	defer db.Close()
+	defer release()
+
+	// The write lock is taken before a slot of the limiter, so statements
+	// waiting for another run's lock do not hold the slots of the database.
+	var conn *sql.Conn
+	if c.WriteLock {
+		var unlock func()
+		if conn, unlock, err = c.writeLockConn(ctx, db); err != nil {
+			return err
+		}
+		defer unlock()
+	}
 
-	_, err = db.ExecContext(ctx, command, args...)
+	unlimit, err := c.limiter.acquire(ctx, c)
 	if err != nil {
 		return err
 	}
+	defer unlimit()
 
-	return nil
+	// Dropped even if the statement failed, as it may have partly applied.
+	defer c.snapshots.invalidate(c)
+
+	// Writes are only retried when they did not take effect, unless the
+	// statement is safe to repeat.
+	err = retry(ctx, c.retryPolicy(), writeRetryable(ctx), func() error {
+		if conn != nil {
+			_, err := conn.ExecContext(ctx, command, args...)
+			return err
+		}
+		_, err := db.ExecContext(ctx, command, args...)
+		return err
+	})
+	if err == nil && c.auditTable != "" {
+		c.auditStatement(ctx, command, args)
+	}
+	return err
 }
 
-func (c *Connector) QueryContext(ctx context.Context, query string, scanner func(*sql.Rows) error, args ...interface{}) error {
-	db, err := c.db()
+func (c *Connector) QueryContext(ctx context.Context, query string, scanner func(*sql.Rows) error, args ...interface{}) (err error) {
+	ctx, span := c.traceStatement(ctx, query, args)
+	defer func() { endSpan(span, err) }()
+
+	db, release, err := c.db(ctx)
 	if err != nil {
 		return err
 	}
-	defer db.Close()
-
-	rows, err := db.QueryContext(ctx, query, args...)
+	defer release()
+
+	// Only the query is retried: a scanner may already have consumed rows
+	// when a later error is returned.
+	var rows *sql.Rows
+	err = retry(ctx, c.retryPolicy(), isTransient, func() error {
+		var err error
+		if conn, ok := heldConn(ctx, db); ok {
+			rows, err = conn.QueryContext(ctx, query, args...)
+			return err
+		}
+		rows, err = db.QueryContext(ctx, query, args...)
+		return err
+	})
 	if err != nil {
 		return err
 	}
@@ -140,53 +397,85 @@ func (c *Connector) QueryContext(ctx context.Context, query string, scanner func
 	return nil
 }
 
-func (c *Connector) QueryRowContext(ctx context.Context, query string, scanner func(*sql.Row) error, args ...interface{}) error {
-	db, err := c.db()
+func (c *Connector) QueryRowContext(ctx context.Context, query string, scanner func(*sql.Row) error, args ...interface{}) (err error) {
+	ctx, span := c.traceStatement(ctx, query, args)
+	defer func() { endSpan(span, err) }()
+
+	db, release, err := c.db(ctx)
 	if err != nil {
 		return err
 	}
-	defer db.Close()
-
-	row := db.QueryRowContext(ctx, query, args...)
-	if row.Err() != nil {
-		return row.Err()
-	}
-
-	return scanner(row)
+	defer release()
+
+	return retry(ctx, c.retryPolicy(), isTransient, func() error {
+		var row *sql.Row
+		if conn, ok := heldConn(ctx, db); ok {
+			row = conn.QueryRowContext(ctx, query, args...)
+		} else {
+			row = db.QueryRowContext(ctx, query, args...)
+		}
+		if row.Err() != nil {
+			return row.Err()
+		}
+		return scanner(row)
+	})
 }
 
-func (c *Connector) db() (*sql.DB, error) {
+// db returns a database handle for the connector and a function to release it.
+// Connectors created by a configured factory share pooled handles, which are
+// only closed with the factory; otherwise a new handle is opened and released
+// by closing it.
+func (c *Connector) db(ctx context.Context) (db *sql.DB, release func(), err error) {
 	if c == nil {
 		panic("No connector")
 	}
+	ctx, span := c.startSpan(ctx, "sql.connect")
+	defer func() { endSpan(span, err) }()
+
+	if c.pool != nil {
+		db, err := c.pool.get(ctx, c)
+		return db, func() {}, err
+	}
 	conn, err := c.connector()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	if db, err := connectLoop(conn, c.Timeout); err != nil {
-		return nil, err
-	} else {
-		return db, nil
+	db, err = connectLoop(ctx, conn, c.Timeout, c.retryPolicy())
+	if err != nil {
+		return nil, nil, err
 	}
+	return db, func() { db.Close() }, nil
 }
 
 func (c *Connector) connector() (driver.Connector, error) {
+	if c.Driver != nil {
+		return c.Driver, nil
+	}
 	query := url.Values{}
 	host := fmt.Sprintf("%s:%s", c.Host, c.Port)
+	var path string
+	if c.Instance != "" {
+		// Without a port, the driver asks the SQL Browser service of the host
+		// for the port of the named instance.
+		host, path = c.Host, c.Instance
+	}
 	if c.Database != "" {
 		query.Set("database", c.Database)
 	}
-	if c.Login != nil || c.AzureLogin != nil {
+	c.TLS.setQuery(query)
+	c.Options.setQuery(query)
+	if c.Login != nil || c.AzureLogin != nil || c.WorkloadIdentity != nil || c.Token != "" {
 		connectionString := (&url.URL{
 			Scheme:   "sqlserver",
 			User:     c.userPassword(),
 			Host:     host,
+			Path:     path,
 			RawQuery: query.Encode(),
 		}).String()
 		if c.Login != nil {
 			return mssql.NewConnector(connectionString)
 		}
-		return mssql.NewAccessTokenConnector(connectionString, func() (string, error) { return c.tokenProvider() })
+		return mssql.NewConnectorWithAccessTokenProvider(connectionString, c.tokenProvider)
 	}
 	if c.FedauthMSI != nil {
 		query.Set("fedauth", "ActiveDirectoryManagedIdentity")
@@ -199,11 +488,48 @@ func (c *Connector) connector() (driver.Connector, error) {
 	connectionString := (&url.URL{
 		Scheme:   "sqlserver",
 		Host:     host,
+		Path:     path,
 		RawQuery: query.Encode(),
 	}).String()
 	return azuread.NewConnector(connectionString)
 }
 
+func (o ConnectionOptions) setQuery(query url.Values) {
+	appName := o.AppName
+	if appName == "" {
+		appName = DefaultAppName
+	}
+	query.Set("app name", appName)
+	if o.ApplicationIntent != "" {
+		query.Set("ApplicationIntent", o.ApplicationIntent)
+		// The driver requires a database for read-only routing
+		if o.ApplicationIntent == "ReadOnly" && query.Get("database") == "" {
+			query.Set("database", "master")
+		}
+	}
+	if o.MultiSubnetFailover {
+		query.Set("MultiSubnetFailover", "true")
+	}
+	if o.PacketSize > 0 {
+		query.Set("packet size", strconv.Itoa(o.PacketSize))
+	}
+}
+
+func (t TLSSettings) setQuery(query url.Values) {
+	// The driver trusts any server certificate unless encrypt is set, so
+	// TrustServerCertificate is only passed together with encrypt.
+	if t.Encrypt != "" {
+		query.Set("encrypt", t.Encrypt)
+		query.Set("TrustServerCertificate", strconv.FormatBool(t.TrustServerCertificate))
+	}
+	if t.HostNameInCertificate != "" {
+		query.Set("hostNameInCertificate", t.HostNameInCertificate)
+	}
+	if t.CACertificatePath != "" {
+		query.Set("certificate", t.CACertificatePath)
+	}
+}
+
 func (c *Connector) userPassword() *url.Userinfo {
 	if c.Login != nil {
 		return url.UserPassword(c.Login.Username, c.Login.Password)
@@ -211,73 +537,80 @@ func (c *Connector) userPassword() *url.Userinfo {
 	return nil
 }
 
-func (c *Connector) tokenProvider() (string, error) {
-	const resourceID = "https://database.windows.net/"
+func (c *Connector) tokenCredential() (azcore.TokenCredential, error) {
+	if c.WorkloadIdentity != nil {
+		admin := c.WorkloadIdentity
+		return azidentity.NewWorkloadIdentityCredential(&azidentity.WorkloadIdentityCredentialOptions{
+			TenantID:      admin.TenantID,
+			ClientID:      admin.ClientID,
+			TokenFilePath: admin.TokenFilePath,
+		})
+	}
 
 	admin := c.AzureLogin
-	cred, err := azidentity.NewClientSecretCredential(
+	if admin.ClientCertificatePath != "" {
+		data, err := os.ReadFile(admin.ClientCertificatePath)
+		if err != nil {
+			return nil, err
+		}
+		var password []byte
+		if admin.ClientCertificatePassword != "" {
+			password = []byte(admin.ClientCertificatePassword)
+		}
+		certs, key, err := azidentity.ParseCertificates(data, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse client certificate %s: %v", admin.ClientCertificatePath, err)
+		}
+		return azidentity.NewClientCertificateCredential(admin.TenantID, admin.ClientID, certs, key, nil)
+	}
+	if admin.ClientSecret == "" {
+		return nil, errors.New("azure_login requires either client_secret or client_certificate_path")
+	}
+	return azidentity.NewClientSecretCredential(
 		admin.TenantID,
 		admin.ClientID,
 		admin.ClientSecret,
 		nil,
 	)
-	if err != nil {
-		return "", fmt.Errorf("failed to create credential: %v", err)
-	}
+}
 
-	token, err := cred.GetToken(context.Background(), policy.TokenRequestOptions{
-		Scopes: []string{resourceID + "/.default"},
-	})
-	if err != nil {
-		return "", fmt.Errorf("failed to get token: %v", err)
+func (c *Connector) tokenProvider(ctx context.Context) (token string, err error) {
+	const resourceID = "https://database.windows.net/"
+
+	if c.Token != "" {
+		return c.Token, nil
 	}
 
-	c.Token = token.Token
+	ctx, span := c.startSpan(ctx, "azure.token")
+	defer func() { endSpan(span, err) }()
 
-	return token.Token, nil
+	return tokens.get(ctx, c.tokenKey(resourceID+"/.default"), c.tokenCredential)
 }
 
-func connectLoop(connector driver.Connector, timeout time.Duration) (*sql.DB, error) {
-	ticker := time.NewTicker(250 * time.Millisecond)
-	defer ticker.Stop()
-
-	timeoutExceeded := time.After(timeout)
-	for {
-		select {
-		case <-timeoutExceeded:
-			return nil, fmt.Errorf("db connection failed after %s timeout", timeout)
-
-		case <-ticker.C:
-			db, err := connect(connector)
-			if err == nil {
-				return db, nil
-			}
-			if strings.Contains(strings.ToLower(err.Error()), "login failed") {
-				return nil, err
-			}
-			if strings.Contains(strings.ToLower(err.Error()), "login error") {
-				return nil, err
-			}
-			if strings.Contains(err.Error(), "error retrieving access token") {
-				return nil, err
-			}
-			if strings.Contains(err.Error(), "AuthenticationFailedError") {
-				return nil, err
-			}
-			if strings.Contains(err.Error(), "credential") {
-				return nil, err
-			}
-			if strings.Contains(err.Error(), "request failed") {
-				return nil, err
-			}
-			log.Println(errors.Wrap(err, "failed to connect to database"))
+// connectLoop opens a database handle, retrying transient errors with the
+// retry policy until the connection timeout.
+func connectLoop(ctx context.Context, connector driver.Connector, timeout time.Duration, policy model.RetryPolicy) (*sql.DB, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var db *sql.DB
+	err := retry(ctx, policy, isTransient, func() error {
+		var err error
+		db, err = connect(ctx, connector)
+		return err
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("db connection failed after %s timeout: %v", timeout, err)
 		}
+		return nil, errors.Wrap(err, "failed to connect to database")
 	}
+	return db, nil
 }
 
-func connect(connector driver.Connector) (*sql.DB, error) {
+func connect(ctx context.Context, connector driver.Connector) (*sql.DB, error) {
 	db := sql.OpenDB(connector)
-	if err := db.Ping(); err != nil {
+	if err := db.PingContext(ctx); err != nil {
 		db.Close()
 		return nil, err
 	}
@@ -292,20 +625,6 @@ func (c *Connector) setDatabase(database *string) *Connector {
 	return c
 }
 
-func (c *Connector) GetMSSQLVersion(ctx context.Context) (string, error) {
-	var version string
-	err := c.QueryRowContext(ctx,"SELECT @@VERSION", func(r *sql.Row) error {
-		return r.Scan(&version)
-	},)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return "", nil
-		}
-		return "", err
-	}
-	return version, nil
-}
-
 // DatabaseExists checks if a database exists in SQL Server
 func (c *Connector) DatabaseExists(ctx context.Context, database string) (bool, error) {
 	cmd := `
@@ -0,0 +1,133 @@
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+	"github.com/pkg/errors"
+)
+
+func TestExecContext_ReadOnly(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	connector.ReadOnly = true
+
+	if err := connector.CreateLogin(context.Background(), "login", "valueIsH8kd$¡", "", "", ""); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected %v, got %v", ErrReadOnly, err)
+	}
+	if err := connector.DataBaseExecuteScript(context.Background(), "db", "CREATE TABLE t (id int)\nGO\nDROP TABLE t"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected %v, got %v", ErrReadOnly, err)
+	}
+	fake.expectStatements(t)
+}
+
+func TestDataBaseExecuteScript_ReadOnlySelect(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	connector.ReadOnly = true
+
+	for _, script := range []string{"SELECT 1; DROP TABLE t", "SELECT * INTO t2 FROM t"} {
+		if err := connector.DataBaseExecuteScript(context.Background(), "db", script); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("expected %v for %q, got %v", ErrReadOnly, script, err)
+		}
+	}
+	fake.expectStatements(t)
+}
+
+func TestQueryRowContext_ReadOnly(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	connector.ReadOnly = true
+
+	login, err := connector.GetLogin(context.Background(), "login")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if login != nil {
+		t.Errorf("expected no login, got %+v", login)
+	}
+	fake.expectStatements(t, fakeStatement{
+		query: "FROM [master].[sys].[sql_logins]",
+		args:  map[string]interface{}{"name": "login"},
+	})
+}
+
+// noServer is resource data without an inline server block or profile.
+type noServer struct{}
+
+func (noServer) GetOk(string) (interface{}, bool) { return nil, false }
+
+func TestGetConnector_AzureLoginCredential(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		azureLogin  map[string]interface{}
+		env         map[string]string
+		secret      string
+		certificate string
+		valid       bool
+	}{
+		{"secret", map[string]interface{}{"client_secret": "secret", "client_certificate_path": ""}, nil, "secret", "", true},
+		{"certificate", map[string]interface{}{"client_secret": "", "client_certificate_path": "/etc/ssl/client.pem"}, nil, "", "/etc/ssl/client.pem", true},
+		{"both", map[string]interface{}{"client_secret": "secret", "client_certificate_path": "/etc/ssl/client.pem"}, nil, "", "", false},
+		{"neither", map[string]interface{}{"client_secret": "", "client_certificate_path": ""}, nil, "", "", false},
+		// The schema defaults both to the environment, so the SDK passes the
+		// exported secret along with the configured certificate.
+		{
+			"certificate with secret in environment",
+			map[string]interface{}{"client_secret": "env", "client_certificate_path": "/etc/ssl/client.pem"},
+			map[string]string{"MSSQL_CLIENT_SECRET": "env"},
+			"", "/etc/ssl/client.pem", true,
+		},
+		{
+			"secret with certificate in environment",
+			map[string]interface{}{"client_secret": "secret", "client_certificate_path": "/etc/ssl/env.pem"},
+			map[string]string{"MSSQL_CLIENT_CERTIFICATE_PATH": "/etc/ssl/env.pem"},
+			"secret", "", true,
+		},
+		{
+			"both in environment",
+			map[string]interface{}{"client_secret": "env", "client_certificate_path": "/etc/ssl/env.pem"},
+			map[string]string{"MSSQL_CLIENT_SECRET": "env", "MSSQL_CLIENT_CERTIFICATE_PATH": "/etc/ssl/env.pem"},
+			"", "", false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("MSSQL_CLIENT_SECRET", "")
+			t.Setenv("MSSQL_CLIENT_CERTIFICATE_PATH", "")
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			azureLogin := map[string]interface{}{"tenant_id": "tenant", "client_id": "client", "client_certificate_password": ""}
+			for k, v := range tc.azureLogin {
+				azureLogin[k] = v
+			}
+			f := GetFactory().Configure(&model.ProviderConfig{Server: map[string]interface{}{
+				"host":                     "localhost",
+				"port":                     "1433",
+				"encrypt":                  "",
+				"trust_server_certificate": false,
+				"host_name_in_certificate": "",
+				"ca_certificate_path":      "",
+				"app_name":                 "",
+				"application_intent":       "",
+				"multi_subnet_failover":    false,
+				"packet_size":              0,
+				"azure_login":              []interface{}{azureLogin},
+			}})
+			defer f.Close()
+
+			connector, err := f.GetConnector("server", noServer{})
+			if !tc.valid {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			login := connector.(*Connector).AzureLogin
+			if login.ClientSecret != tc.secret || login.ClientCertificatePath != tc.certificate {
+				t.Errorf("expected secret %q and certificate %q, got %q and %q", tc.secret, tc.certificate, login.ClientSecret, login.ClientCertificatePath)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package sql
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// tokenRefreshMargin is how long before its expiry a cached token is
+// considered stale, so a connection is never opened with a token that
+// expires during the login handshake.
+const tokenRefreshMargin = 5 * time.Minute
+
+// tokens is shared by all connectors of the process, so a plan touching many
+// resources requests one Entra token per principal instead of one per
+// connection.
+var tokens = &tokenCache{entries: make(map[tokenKey]*tokenEntry)}
+
+// tokenKey identifies a token by the principal, the credential it was
+// requested with and the scope, so a principal configured with different
+// secrets or certificates does not share tokens across them.
+type tokenKey struct {
+	tenantID   string
+	clientID   string
+	credential string
+	// secretHash is the SHA-256 of the secret, or of the path of the
+	// certificate or federated token file, of the credential.
+	secretHash string
+	scope      string
+}
+
+// tokenKey returns the key of the tokens of c for scope.
+func (c *Connector) tokenKey(scope string) tokenKey {
+	key := tokenKey{scope: scope}
+	if admin := c.WorkloadIdentity; admin != nil {
+		key.tenantID, key.clientID = admin.TenantID, admin.ClientID
+		key.credential, key.secretHash = "workload_identity", hashSecret(admin.TokenFilePath)
+		return key
+	}
+	admin := c.AzureLogin
+	key.tenantID, key.clientID = admin.TenantID, admin.ClientID
+	if admin.ClientCertificatePath != "" {
+		key.credential, key.secretHash = "client_certificate", hashSecret(admin.ClientCertificatePath+"\x00"+admin.ClientCertificatePassword)
+	} else {
+		key.credential, key.secretHash = "client_secret", hashSecret(admin.ClientSecret)
+	}
+	return key
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+type tokenEntry struct {
+	mu    sync.Mutex
+	token azcore.AccessToken
+}
+
+type tokenCache struct {
+	mu      sync.Mutex
+	entries map[tokenKey]*tokenEntry
+}
+
+func (t *tokenCache) entry(key tokenKey) *tokenEntry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	e, ok := t.entries[key]
+	if !ok {
+		e = &tokenEntry{}
+		t.entries[key] = e
+	}
+	return e
+}
+
+// get returns the cached token for key, or requests a new one from the
+// credential returned by newCredential when there is none or it is about to
+// expire.
+func (t *tokenCache) get(ctx context.Context, key tokenKey, newCredential func() (azcore.TokenCredential, error)) (string, error) {
+	e := t.entry(key)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.token.Token != "" && time.Now().Add(tokenRefreshMargin).Before(e.token.ExpiresOn) {
+		return e.token.Token, nil
+	}
+
+	cred, err := newCredential()
+	if err != nil {
+		return "", fmt.Errorf("failed to create credential: %v", err)
+	}
+	token, err := cred.GetToken(ctx, policy.TokenRequestOptions{
+		Scopes: []string{key.scope},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get token: %v", err)
+	}
+
+	e.token = token
+	return token.Token, nil
+}
@@ -0,0 +1,112 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// fakeCredential issues tokens numbered by request, valid for validity.
+type fakeCredential struct {
+	requests int
+	validity time.Duration
+}
+
+func (f *fakeCredential) GetToken(context.Context, policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	f.requests++
+	return azcore.AccessToken{Token: fmt.Sprintf("token%d", f.requests), ExpiresOn: time.Now().Add(f.validity)}, nil
+}
+
+func (f *fakeCredential) newCredential() (azcore.TokenCredential, error) {
+	return f, nil
+}
+
+func newTokenCache() *tokenCache {
+	return &tokenCache{entries: make(map[tokenKey]*tokenEntry)}
+}
+
+func TestTokenCache_Hit(t *testing.T) {
+	cache, cred := newTokenCache(), &fakeCredential{validity: time.Hour}
+	key := tokenKey{tenantID: "tenant", clientID: "client", scope: "scope"}
+
+	for i := 0; i < 2; i++ {
+		token, err := cache.get(context.Background(), key, cred.newCredential)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != "token1" {
+			t.Errorf("expected token1, got %s", token)
+		}
+	}
+	if cred.requests != 1 {
+		t.Errorf("expected one token request, got %d", cred.requests)
+	}
+}
+
+func TestTokenCache_RefreshesExpiringTokens(t *testing.T) {
+	// Tokens expiring within the refresh margin are not reused.
+	cache, cred := newTokenCache(), &fakeCredential{validity: tokenRefreshMargin / 2}
+	key := tokenKey{tenantID: "tenant", clientID: "client", scope: "scope"}
+
+	if _, err := cache.get(context.Background(), key, cred.newCredential); err != nil {
+		t.Fatal(err)
+	}
+	cred.validity = time.Hour
+	token, err := cache.get(context.Background(), key, cred.newCredential)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "token2" {
+		t.Errorf("expected the refreshed token2, got %s", token)
+	}
+	if token, _ = cache.get(context.Background(), key, cred.newCredential); token != "token2" {
+		t.Errorf("expected the refreshed token to be cached, got %s", token)
+	}
+	if cred.requests != 2 {
+		t.Errorf("expected two token requests, got %d", cred.requests)
+	}
+}
+
+func TestTokenKey_Credential(t *testing.T) {
+	secret := &Connector{AzureLogin: &AzureLogin{TenantID: "tenant", ClientID: "client", ClientSecret: "secret"}}
+	otherSecret := &Connector{AzureLogin: &AzureLogin{TenantID: "tenant", ClientID: "client", ClientSecret: "other"}}
+	certificate := &Connector{AzureLogin: &AzureLogin{TenantID: "tenant", ClientID: "client", ClientCertificatePath: "/etc/ssl/client.pem"}}
+	workload := &Connector{WorkloadIdentity: &WorkloadIdentity{TenantID: "tenant", ClientID: "client", TokenFilePath: "/var/run/token"}}
+
+	if secret.tokenKey("scope") != secret.tokenKey("scope") {
+		t.Error("expected the same key for the same credential")
+	}
+	keys := map[tokenKey]string{}
+	for name, c := range map[string]*Connector{"secret": secret, "other secret": otherSecret, "certificate": certificate, "workload identity": workload} {
+		key := c.tokenKey("scope")
+		if other, ok := keys[key]; ok {
+			t.Errorf("expected %s and %s to have different keys", name, other)
+		}
+		keys[key] = name
+	}
+	if key := secret.tokenKey("scope"); key.secretHash == "secret" || key.credential != "client_secret" {
+		t.Errorf("expected the hashed client secret in the key, got %+v", key)
+	}
+}
+
+func TestTokenCache_SeparatesCredentials(t *testing.T) {
+	cache, cred := newTokenCache(), &fakeCredential{validity: time.Hour}
+	secret := &Connector{AzureLogin: &AzureLogin{TenantID: "tenant", ClientID: "client", ClientSecret: "secret"}}
+	rotated := &Connector{AzureLogin: &AzureLogin{TenantID: "tenant", ClientID: "client", ClientSecret: "rotated"}}
+
+	first, err := cache.get(context.Background(), secret.tokenKey("scope"), cred.newCredential)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := cache.get(context.Background(), rotated.tokenKey("scope"), cred.newCredential)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == second {
+		t.Errorf("expected a new token for the rotated secret, got %s twice", first)
+	}
+}
@@ -0,0 +1,35 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer records spans with the tracer provider installed by the provider
+// configuration; until then, and when tracing is disabled, it does nothing.
+var tracer = otel.Tracer("github.com/Jake-Barrow/terraform-provider-mssql/sql")
+
+func (c *Connector) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		attribute.String("db.system", "mssql"),
+		attribute.String("server.address", c.Host),
+		attribute.String("db.namespace", c.Database),
+	)
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan ends span, recording err as its status. sql.ErrNoRows only means
+// that an object does not exist, so it is not recorded.
+func endSpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
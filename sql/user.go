@@ -9,34 +9,82 @@ import (
 )
 
 func (c *Connector) GetUser(ctx context.Context, database, username string) (*model.User, error) {
+	snapshot, err := c.snapshot(ctx, database)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		user *model.User
+		sid  []byte
+	)
+	// Users missing from the snapshot are queried, as their name may differ
+	// in case only, or they may have been created since it was read.
+	if p, ok := snapshot.principal(username); ok {
+		user = &model.User{
+			PrincipalID:     p.id,
+			Username:        p.name,
+			TypeStr:         p.typ,
+			AuthType:        p.authType,
+			DefaultSchema:   p.defaultSchema,
+			DefaultLanguage: p.defaultLanguage,
+			SIDStr:          p.sidStr,
+			LoginName:       p.loginName,
+			Roles:           append(make([]string, 0, len(p.roles)), p.roles...),
+		}
+		sid = p.sid
+	} else if user, sid, err = c.queryUser(ctx, database, username); err != nil || user == nil {
+		return nil, err
+	}
+	if user.AuthType == "INSTANCE" && user.LoginName == "" {
+		cmd := "SELECT name FROM [sys].[sql_logins] WHERE sid = @sid"
+		c.Database = "master"
+		err = c.QueryRowContext(ctx, cmd,
+			func(r *sql.Row) error {
+				return r.Scan(&user.LoginName)
+			},
+			sql.Named("sid", sid),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if user.AuthType == "EXTERNAL" && strings.HasSuffix(user.SIDStr, "AADE") {
+		cmd := "SELECT name FROM [sys].[server_principals] WHERE type NOT IN ('G', 'R') AND CONVERT(varchar(64), sid, 1) = LEFT(CONVERT(varchar(64), @sid, 1), 34)"
+		c.Database = "master"
+		err = c.QueryRowContext(ctx, cmd,
+			func(r *sql.Row) error {
+				return r.Scan(&user.LoginName)
+			},
+			sql.Named("sid", sid),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// queryUser reads the user with the roles it is a direct member of, as the
+// snapshot does.
+func (c *Connector) queryUser(ctx context.Context, database, username string) (*model.User, []byte, error) {
+	caps, err := c.setDatabase(&database).GetServerCapabilities(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
 	cmd := `DECLARE @stmt nvarchar(max)
-			IF @@VERSION LIKE 'Microsoft SQL Azure%'
+			IF @azure = 1
 				BEGIN
-					SET @stmt = 'WITH CTE_Roles (principal_id, role_principal_id) AS ' +
-								'(' +
-								'  SELECT member_principal_id, role_principal_id FROM [sys].[database_role_members] WHERE member_principal_id = DATABASE_PRINCIPAL_ID(' + QuoteName(@username, '''') + ')' +
-								'  UNION ALL ' +
-								'  SELECT member_principal_id, drm.role_principal_id FROM [sys].[database_role_members] drm' +
-								'    INNER JOIN CTE_Roles cr ON drm.member_principal_id = cr.role_principal_id' +
-								') ' +
-								'SELECT p.principal_id, p.name, p.type, p.authentication_type_desc, COALESCE(p.default_schema_name, ''''), COALESCE(p.default_language_name, ''''), p.sid, CONVERT(VARCHAR(85), p.sid, 1) AS sidStr, '''', COALESCE(STRING_AGG(USER_NAME(r.role_principal_id), '',''), '''') ' +
+					SET @stmt = 'SELECT p.principal_id, p.name, p.type, p.authentication_type_desc, COALESCE(p.default_schema_name, ''''), COALESCE(p.default_language_name, ''''), p.sid, CONVERT(VARCHAR(85), p.sid, 1) AS sidStr, '''', COALESCE(STRING_AGG(USER_NAME(r.role_principal_id), '',''), '''') ' +
 								'FROM [sys].[database_principals] p' +
-								'  LEFT JOIN CTE_Roles r ON p.principal_id = r.principal_id ' +
+								'  LEFT JOIN [sys].[database_role_members] r ON p.principal_id = r.member_principal_id ' +
 								'WHERE p.name = ' + QuoteName(@username, '''') + ' ' +
 								'GROUP BY p.principal_id, p.name, p.type, p.authentication_type_desc, p.default_schema_name, p.default_language_name, p.sid'
 				END
 			ELSE
 				BEGIN
-					SET @stmt = 'WITH CTE_Roles (principal_id, role_principal_id) AS ' +
-								'(' +
-								'  SELECT member_principal_id, role_principal_id FROM ' + QuoteName(@database) + '.[sys].[database_role_members] WHERE member_principal_id = DATABASE_PRINCIPAL_ID(' + QuoteName(@username, '''') + ')' +
-								'  UNION ALL ' +
-								'  SELECT member_principal_id, drm.role_principal_id FROM ' + QuoteName(@database) + '.[sys].[database_role_members] drm' +
-								'    INNER JOIN CTE_Roles cr ON drm.member_principal_id = cr.role_principal_id' +
-								') ' +
-								'SELECT p.principal_id, p.name, p.type, p.authentication_type_desc, COALESCE(p.default_schema_name, ''''), COALESCE(p.default_language_name, ''''), p.sid, CONVERT(VARCHAR(85), p.sid, 1) AS sidStr, COALESCE(sl.name, ''''), COALESCE(STRING_AGG(USER_NAME(r.role_principal_id), '',''), '''') ' +
+					SET @stmt = 'SELECT p.principal_id, p.name, p.type, p.authentication_type_desc, COALESCE(p.default_schema_name, ''''), COALESCE(p.default_language_name, ''''), p.sid, CONVERT(VARCHAR(85), p.sid, 1) AS sidStr, COALESCE(sl.name, ''''), COALESCE(STRING_AGG(USER_NAME(r.role_principal_id), '',''), '''') ' +
 								'FROM ' + QuoteName(@database) + '.[sys].[database_principals] p' +
-								'  LEFT JOIN CTE_Roles r ON p.principal_id = r.principal_id ' +
+								'  LEFT JOIN ' + QuoteName(@database) + '.[sys].[database_role_members] r ON p.principal_id = r.member_principal_id ' +
 								'  LEFT JOIN [master].[sys].[sql_logins] sl ON p.sid = sl.sid ' +
 								'WHERE p.name = ' + QuoteName(@username, '''') + ' ' +
 								'GROUP BY p.principal_id, p.name, p.type, p.authentication_type_desc, p.default_schema_name, p.default_language_name, p.sid, sl.name'
@@ -47,7 +95,7 @@ func (c *Connector) GetUser(ctx context.Context, database, username string) (*mo
 		sid   []byte
 		roles string
 	)
-	err := c.
+	err = c.
 		setDatabase(&database).
 		QueryRowContext(ctx, cmd,
 			func(r *sql.Row) error {
@@ -55,48 +103,27 @@ func (c *Connector) GetUser(ctx context.Context, database, username string) (*mo
 			},
 			sql.Named("database", database),
 			sql.Named("username", username),
+			sql.Named("azure", caps.IsAzure()),
 		)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, nil
-		}
-		return nil, err
-	}
-	if user.AuthType == "INSTANCE" && user.LoginName == "" {
-		cmd = "SELECT name FROM [sys].[sql_logins] WHERE sid = @sid"
-		c.Database = "master"
-		err = c.QueryRowContext(ctx, cmd,
-			func(r *sql.Row) error {
-				return r.Scan(&user.LoginName)
-			},
-			sql.Named("sid", sid),
-		)
-		if err != nil {
-			return nil, err
-		}
-	}
-	if user.AuthType == "EXTERNAL" && strings.HasSuffix(user.SIDStr, "AADE") {
-		cmd = "SELECT name FROM [sys].[server_principals] WHERE type NOT IN ('G', 'R') AND CONVERT(varchar(64), sid, 1) = LEFT(CONVERT(varchar(64), @sid, 1), 34)"
-		c.Database = "master"
-		err = c.QueryRowContext(ctx, cmd,
-			func(r *sql.Row) error {
-				return r.Scan(&user.LoginName)
-			},
-			sql.Named("sid", sid),
-		)
-		if err != nil {
-			return nil, err
+			return nil, nil, nil
 		}
+		return nil, nil, err
 	}
 	if roles == "" {
 		user.Roles = make([]string, 0)
 	} else {
 		user.Roles = strings.Split(roles, ",")
 	}
-	return &user, nil
+	return &user, sid, nil
 }
 
 func (c *Connector) CreateUser(ctx context.Context, database string, user *model.User) error {
+	caps, err := c.setDatabase(&database).GetServerCapabilities(ctx)
+	if err != nil {
+		return err
+	}
 	cmd := `DECLARE @stmt nvarchar(max)
 			DECLARE @language nvarchar(max) = @defaultLanguage
 			IF @language = '' SET @language = NULL
@@ -110,14 +137,14 @@ func (c *Connector) CreateUser(ctx context.Context, database string, user *model
 				BEGIN
 					SET @stmt = 'CREATE USER ' + QuoteName(@username) + ' WITH PASSWORD = ' + QuoteName(@password, '''') + ', ' +
 								'DEFAULT_SCHEMA = ' + QuoteName(@defaultSchema)
-					IF NOT @@VERSION LIKE 'Microsoft SQL Azure%'
+					IF @azure = 0
 						BEGIN
 							SET @stmt = @stmt + ', DEFAULT_LANGUAGE = ' + Coalesce(QuoteName(@language), 'NONE')
 						END
 				END
 			IF @loginName = '' AND @username != '' AND @password = ''
 				BEGIN
-					IF @@VERSION LIKE 'Microsoft SQL Azure%'
+					IF @azure = 1
 						BEGIN
 							IF @objectId != ''
 								BEGIN
@@ -194,10 +221,17 @@ func (c *Connector) CreateUser(ctx context.Context, database string, user *model
 			sql.Named("defaultSchema", user.DefaultSchema),
 			sql.Named("defaultLanguage", user.DefaultLanguage),
 			sql.Named("roles", strings.Join(user.Roles, ",")),
+			sql.Named("azure", caps.IsAzure()),
 		)
 }
 
+// UpdateUser alters the user and, unless user.Roles is nil, drops it from the
+// roles not in user.Roles and adds it to the others.
 func (c *Connector) UpdateUser(ctx context.Context, database string, user *model.User) error {
+	caps, err := c.setDatabase(&database).GetServerCapabilities(ctx)
+	if err != nil {
+		return err
+	}
 	cmd := `DECLARE @stmt nvarchar(max)
 			SET @stmt = 'ALTER USER ' + QuoteName(@username) + ' '
 			DECLARE @language nvarchar(max) = @defaultLanguage
@@ -208,7 +242,7 @@ func (c *Connector) UpdateUser(ctx context.Context, database string, user *model
 					SET @stmt = @stmt + ', PASSWORD = ' + QuoteName(@password, '''')
 				END
 			DECLARE @auth_type nvarchar(max) = (SELECT authentication_type_desc FROM [sys].[database_principals] WHERE name = @username)
-			IF NOT @@VERSION LIKE 'Microsoft SQL Azure%' AND @auth_type != 'INSTANCE'
+			IF @azure = 0 AND @auth_type != 'INSTANCE'
 				BEGIN
 					SET @stmt = @stmt + ', DEFAULT_LANGUAGE = ' + Coalesce(QuoteName(@language), 'NONE')
 				END
@@ -243,6 +277,7 @@ func (c *Connector) UpdateUser(ctx context.Context, database string, user *model
 			END
 			EXEC sp_releaseapplock @Resource = 'create_func';
 			COMMIT TRANSACTION;
+			IF @updateRoles = 1
 			SET @stmt = @stmt + '; ' +
 									'DECLARE @sql nvarchar(max);' +
 									'DECLARE @role nvarchar(max);' +
@@ -278,6 +313,8 @@ func (c *Connector) UpdateUser(ctx context.Context, database string, user *model
 			sql.Named("defaultSchema", user.DefaultSchema),
 			sql.Named("defaultLanguage", user.DefaultLanguage),
 			sql.Named("roles", strings.Join(user.Roles, ",")),
+			sql.Named("updateRoles", user.Roles != nil),
+			sql.Named("azure", caps.IsAzure()),
 		)
 }
 
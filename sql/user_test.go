@@ -0,0 +1,209 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	"github.com/Jake-Barrow/terraform-provider-mssql/mssql/model"
+)
+
+func TestCreateUser_Login(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+
+	err := connector.CreateUser(context.Background(), "db", &model.User{
+		Username:      "user",
+		LoginName:     "login",
+		DefaultSchema: "dbo",
+		Roles:         []string{"db_datareader", "db_datawriter"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake.expectStatements(t, fakeStatement{
+		query: "'CREATE USER ' + QuoteName(@username) + ' FOR LOGIN ' + QuoteName(@loginName)",
+		args: map[string]interface{}{
+			"database":        "db",
+			"username":        "user",
+			"objectId":        "",
+			"loginName":       "login",
+			"password":        "",
+			"authType":        "",
+			"typeStr":         "",
+			"defaultSchema":   "dbo",
+			"defaultLanguage": "",
+			"roles":           "db_datareader,db_datawriter",
+			"azure":           false,
+		},
+	})
+}
+
+func TestCreateUser_AzureSQLDatabase(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	fake.capabilities.EngineEdition = model.EngineEditionSQLDatabase
+
+	err := connector.CreateUser(context.Background(), "db", &model.User{
+		Username:      "user",
+		Password:      "valueIsH8kd$¡",
+		DefaultSchema: "dbo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake.expectStatements(t, fakeStatement{
+		query: "'CREATE USER ' + QuoteName(@username) + ' WITH PASSWORD = '",
+		args: map[string]interface{}{
+			"database":        "db",
+			"username":        "user",
+			"objectId":        "",
+			"loginName":       "",
+			"password":        "valueIsH8kd$¡",
+			"authType":        "",
+			"typeStr":         "",
+			"defaultSchema":   "dbo",
+			"defaultLanguage": "",
+			"roles":           "",
+			"azure":           true,
+		},
+	})
+}
+
+func TestUpdateUser(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+
+	err := connector.UpdateUser(context.Background(), "", &model.User{
+		Username:        "user",
+		DefaultSchema:   "sales",
+		DefaultLanguage: "russian",
+		Roles:           []string{"db_owner"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake.expectStatements(t, fakeStatement{
+		query: "'ALTER USER ' + QuoteName(@username)",
+		args: map[string]interface{}{
+			"database":        "master",
+			"username":        "user",
+			"password":        "",
+			"defaultSchema":   "sales",
+			"defaultLanguage": "russian",
+			"roles":           "db_owner",
+			"updateRoles":     true,
+			"azure":           false,
+		},
+	})
+}
+
+func TestUpdateUser_KeepRoles(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+
+	err := connector.UpdateUser(context.Background(), "", &model.User{
+		Username:      "user",
+		DefaultSchema: "sales",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake.expectStatements(t, fakeStatement{
+		query: "IF @updateRoles = 1",
+		args: map[string]interface{}{
+			"database":        "master",
+			"username":        "user",
+			"password":        "",
+			"defaultSchema":   "sales",
+			"defaultLanguage": "",
+			"roles":           "",
+			"updateRoles":     false,
+			"azure":           false,
+		},
+	})
+}
+
+func TestGetUser(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	fake.addRows(
+		[]string{"principal_id", "name", "type", "authentication_type_desc", "default_schema_name", "default_language_name", "sid", "sidStr", "login_name", "roles"},
+		[]driver.Value{int64(5), "user", "S", "DATABASE", "dbo", "", []byte{0x01}, "0x01", "", "db_datareader,db_datawriter"},
+	)
+
+	user, err := connector.GetUser(context.Background(), "db", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &model.User{
+		PrincipalID:   5,
+		Username:      "user",
+		TypeStr:       "S",
+		AuthType:      "DATABASE",
+		DefaultSchema: "dbo",
+		SIDStr:        "0x01",
+		Roles:         []string{"db_datareader", "db_datawriter"},
+	}
+	if !reflect.DeepEqual(user, expected) {
+		t.Errorf("expected %+v, got %+v", expected, user)
+	}
+	fake.expectStatements(t, fakeStatement{
+		query: "FROM ' + QuoteName(@database) + '.[sys].[database_principals] p",
+		args: map[string]interface{}{
+			"database": "db",
+			"username": "user",
+			"azure":    false,
+		},
+	})
+}
+
+func TestGetUser_InstanceLogin(t *testing.T) {
+	connector, fake := newFakeConnector(t)
+	fake.addRows(
+		[]string{"principal_id", "name", "type", "authentication_type_desc", "default_schema_name", "default_language_name", "sid", "sidStr", "login_name", "roles"},
+		[]driver.Value{int64(5), "user", "S", "INSTANCE", "dbo", "", []byte{0x01}, "0x01", "", ""},
+	)
+	fake.addRows([]string{"name"}, []driver.Value{"login"})
+
+	user, err := connector.GetUser(context.Background(), "db", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user.LoginName != "login" {
+		t.Errorf("expected login name login, got %q", user.LoginName)
+	}
+	if len(user.Roles) != 0 {
+		t.Errorf("expected no roles, got %v", user.Roles)
+	}
+	fake.expectStatements(t,
+		fakeStatement{
+			query: "[sys].[database_principals] p",
+			args: map[string]interface{}{
+				"database": "db",
+				"username": "user",
+				"azure":    false,
+			},
+		},
+		fakeStatement{
+			query: "SELECT name FROM [sys].[sql_logins] WHERE sid = @sid",
+			args: map[string]interface{}{
+				"sid": []byte{0x01},
+			},
+		},
+	)
+}
+
+func TestGetUser_NotFound(t *testing.T) {
+	connector, _ := newFakeConnector(t)
+
+	user, err := connector.GetUser(context.Background(), "db", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user != nil {
+		t.Errorf("expected no user, got %+v", user)
+	}
+}